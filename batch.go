@@ -0,0 +1,184 @@
+package trendyol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WaitOptions configures how WaitForBatch polls a batch request
+type WaitOptions struct {
+	Interval    time.Duration // delay after the first poll, default 2s
+	MaxInterval time.Duration // upper bound for the delay, default 30s
+	Backoff     float64       // delay multiplier applied after every poll, default 1.5
+	Timeout     time.Duration // overall limit; zero relies on ctx only
+}
+
+func (o *WaitOptions) withDefaults() WaitOptions {
+	var w WaitOptions
+	if o != nil {
+		w = *o
+	}
+	if w.Interval <= 0 {
+		w.Interval = 2 * time.Second
+	}
+	if w.MaxInterval <= 0 {
+		w.MaxInterval = 30 * time.Second
+	}
+	if w.MaxInterval < w.Interval {
+		w.MaxInterval = w.Interval
+	}
+	if w.Backoff < 1 {
+		w.Backoff = 1.5
+	}
+	return w
+}
+
+// BatchResult is a finished batch request with typed per-item results
+type BatchResult struct {
+	Status   *BatchStatusResponse
+	Items    []BatchResultItem
+	Failures map[string][]string // failure reasons grouped by barcode
+}
+
+// BatchResultItem is a single batch item decoded back into its request type.
+// Exactly one of Product or PriceInventory is set when the request item could
+// be decoded; delete requests only carry the barcode.
+type BatchResultItem struct {
+	Barcode        string
+	Status         string
+	FailureReasons []string
+	Product        *Product
+	PriceInventory *PriceInventoryItem
+	Raw            json.RawMessage
+}
+
+// Failed reports whether the item was rejected
+func (i BatchResultItem) Failed() bool {
+	return i.Status == BatchItemStatusFailed || len(i.FailureReasons) > 0
+}
+
+// Succeeded reports whether every item of the batch succeeded
+func (r *BatchResult) Succeeded() bool {
+	return len(r.Failures) == 0 && r.Status.FailedItemCount == 0
+}
+
+// FailedItems returns the rejected items
+func (r *BatchResult) FailedItems() []BatchResultItem {
+	var out []BatchResultItem
+	for _, it := range r.Items {
+		if it.Failed() {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// WaitForBatch polls until the batch leaves IN_PROGRESS. A FAILED or REJECTED
+// batch is decoded like a completed one and returned along with an error.
+func (s *productService) WaitForBatch(ctx context.Context, batchRequestID string, opts *WaitOptions) (*BatchResult, error) {
+	o := opts.withDefaults()
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	delay := o.Interval
+	for {
+		status, err := s.GetBatchStatus(ctx, batchRequestID)
+		if err != nil {
+			return nil, err
+		}
+		switch status.Status {
+		case BatchStatusCompleted:
+			return newBatchResult(status)
+		case BatchStatusFailed, BatchStatusRejected:
+			result, err := newBatchResult(status)
+			if err != nil {
+				return nil, err
+			}
+			return result, fmt.Errorf("batch %s finished with status %q", batchRequestID, status.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("batch %s not completed (last status %q): %w", batchRequestID, status.Status, ctx.Err())
+		case <-time.After(delay):
+		}
+
+		delay = time.Duration(float64(delay) * o.Backoff)
+		if delay > o.MaxInterval {
+			delay = o.MaxInterval
+		}
+	}
+}
+
+// newBatchResult decodes the untyped request items of a completed batch
+func newBatchResult(status *BatchStatusResponse) (*BatchResult, error) {
+	result := &BatchResult{
+		Status:   status,
+		Items:    make([]BatchResultItem, len(status.Items)),
+		Failures: map[string][]string{},
+	}
+	inventory := strings.Contains(strings.ToLower(status.BatchRequestType), "inventory")
+
+	for i, it := range status.Items {
+		item, err := decodeBatchItem(it, inventory)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode batch item %d: %w", i, err)
+		}
+		result.Items[i] = item
+		if item.Failed() {
+			result.Failures[item.Barcode] = append(result.Failures[item.Barcode], item.FailureReasons...)
+		}
+	}
+	return result, nil
+}
+
+func decodeBatchItem(it BatchResponseItem, inventory bool) (BatchResultItem, error) {
+	item := BatchResultItem{
+		Status:         it.Status,
+		FailureReasons: it.FailureReasons,
+	}
+	if it.RequestItem == nil {
+		return item, nil
+	}
+
+	raw, err := json.Marshal(it.RequestItem)
+	if err != nil {
+		return item, err
+	}
+	item.Raw = raw
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return item, err
+	}
+	if bc, ok := fields["barcode"]; ok {
+		_ = json.Unmarshal(bc, &item.Barcode)
+	}
+
+	_, hasTitle := fields["title"]
+	_, hasMainID := fields["productMainId"]
+	_, hasPrice := fields["salePrice"]
+	_, hasQuantity := fields["quantity"]
+
+	switch {
+	case hasTitle || hasMainID:
+		var p Product
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return item, err
+		}
+		item.Product = &p
+	case inventory || hasPrice || hasQuantity:
+		var pi PriceInventoryItem
+		if err := json.Unmarshal(raw, &pi); err != nil {
+			return item, err
+		}
+		item.PriceInventory = &pi
+	}
+	return item, nil
+}
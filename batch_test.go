@@ -0,0 +1,144 @@
+package trendyol_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+func fixtureProduct(barcode string) trendyol.Product {
+	return trendyol.Product{
+		Barcode:       barcode,
+		Title:         "Pamuk Hoodie",
+		ProductMainID: "HOOD-" + barcode,
+		BrandID:       trendyoltest.FixtureBrandID,
+		CategoryID:    trendyoltest.FixtureCategoryID,
+		Quantity:      5,
+		StockCode:     "STK-" + barcode,
+		ListPrice:     249.90,
+		SalePrice:     149.90,
+		CurrencyType:  "TRY",
+		VATRate:       20,
+		Images:        []trendyol.ProductImage{{URL: "https://example.com/img.jpg"}},
+		Attributes: []trendyol.ProductAttribute{
+			{AttributeID: trendyoltest.FixtureOriginAttribute, AttributeValueID: trendyoltest.FixtureOriginTR},
+		},
+	}
+}
+
+var fastWait = &trendyol.WaitOptions{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+// TestWaitForBatchProducts batch tamamlanana kadar beklendiğini, kalemlerin Product
+// tipine çözüldüğünü ve hataların barkoda göre gruplandığını doğrular.
+func TestWaitForBatchProducts(t *testing.T) {
+	srv := trendyoltest.NewServer(trendyoltest.WithBatchPolls(3))
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	bad := fixtureProduct("BAD-1")
+	bad.Images = nil
+	batch, err := client.Products.Create(ctx, []trendyol.Product{fixtureProduct("OK-1"), bad})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	result, err := client.Products.WaitForBatch(ctx, batch.BatchRequestID, fastWait)
+	if err != nil {
+		t.Fatalf("WaitForBatch: %v", err)
+	}
+	if result.Status.Status != trendyol.BatchStatusCompleted {
+		t.Fatalf("COMPLETED beklendi, gelen %s", result.Status.Status)
+	}
+	if result.Succeeded() {
+		t.Fatal("başarısız kalem varken Succeeded false olmalı")
+	}
+	if result.Items[0].Product == nil || result.Items[0].Product.Title != "Pamuk Hoodie" {
+		t.Errorf("kalem Product olarak çözülmeliydi: %+v", result.Items[0])
+	}
+	if reasons := result.Failures["BAD-1"]; len(reasons) == 0 {
+		t.Errorf("BAD-1 için hata nedeni beklendi: %+v", result.Failures)
+	}
+	if len(result.FailedItems()) != 1 {
+		t.Errorf("1 başarısız kalem beklendi, gelen %d", len(result.FailedItems()))
+	}
+	if n := len(srv.Calls(trendyol.EndpointGetBatchRequestResultKey)); n != 4 {
+		t.Errorf("4 durum sorgusu beklendi, gelen %d", n)
+	}
+}
+
+// TestWaitForBatchInventory stok/fiyat batch kalemlerinin PriceInventoryItem olarak çözüldüğünü doğrular.
+func TestWaitForBatchInventory(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(fixtureProduct("OK-1"))
+	client := srv.Client()
+	ctx := context.Background()
+
+	batch, err := client.PriceInventory.Update(ctx, []trendyol.PriceInventoryItem{{Barcode: "OK-1", Quantity: 3, SalePrice: 10, ListPrice: 12}})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	result, err := client.Products.WaitForBatch(ctx, batch.BatchRequestID, fastWait)
+	if err != nil {
+		t.Fatalf("WaitForBatch: %v", err)
+	}
+	if !result.Succeeded() {
+		t.Fatalf("batch başarılı olmalıydı: %+v", result.Failures)
+	}
+	pi := result.Items[0].PriceInventory
+	if pi == nil || pi.Quantity != 3 || result.Items[0].Product != nil {
+		t.Errorf("kalem PriceInventoryItem olarak çözülmeliydi: %+v", result.Items[0])
+	}
+}
+
+// TestWaitForBatchTimeout zaman aşımında bağlam hatasının sarmalandığını doğrular.
+func TestWaitForBatchTimeout(t *testing.T) {
+	srv := trendyoltest.NewServer(trendyoltest.WithBatchPolls(1000))
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	batch, err := client.Products.Create(ctx, []trendyol.Product{fixtureProduct("OK-1")})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	opts := *fastWait
+	opts.Timeout = 30 * time.Millisecond
+	if _, err := client.Products.WaitForBatch(ctx, batch.BatchRequestID, &opts); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("DeadlineExceeded beklendi, gelen %v", err)
+	}
+}
+
+// TestWaitForBatchFailed başarısız biten bir batch için beklemenin sonucu
+// hatayla birlikte hemen döndürdüğünü doğrular.
+func TestWaitForBatchFailed(t *testing.T) {
+	srv := trendyoltest.NewServer(trendyoltest.WithBatchPolls(1))
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	batch, err := client.Products.Create(ctx, []trendyol.Product{fixtureProduct("FAIL-1")})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !srv.SetBatchStatus(batch.BatchRequestID, trendyol.BatchStatusFailed) {
+		t.Fatal("batch bulunamadı")
+	}
+	opts := *fastWait
+	opts.Timeout = time.Second
+	result, err := client.Products.WaitForBatch(ctx, batch.BatchRequestID, &opts)
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("FAILED statüsü için hata beklendi, gelen %v", err)
+	}
+	if result == nil || result.Status.Status != trendyol.BatchStatusFailed || len(result.Items) != 1 {
+		t.Fatalf("başarısız batch sonucu hatayla birlikte dönmeliydi: %+v", result)
+	}
+	if calls := srv.Calls(trendyol.EndpointGetBatchRequestResultKey); len(calls) != 2 {
+		t.Errorf("FAILED görülünce bekleme bitmeliydi, %d sorgu atıldı", len(calls))
+	}
+}
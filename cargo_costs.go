@@ -0,0 +1,231 @@
+package trendyol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// cargoLookupSize is the number of package IDs looked up per order query
+const cargoLookupSize = 50
+
+// CargoCost is the shipping charge of one package joined with its order
+type CargoCost struct {
+	InvoiceSerialNumber string
+	OrderNumber         string
+	PackageID           int64
+	Provider            string
+	City                string
+	Amount              float64
+	BilledDeci          float64
+	DeclaredDeci        float64 // Order.CargoDeci, set through UpdateBoxInfo
+	OrderFound          bool
+}
+
+// Deci returns the billed deci, or the declared one when the invoice carries none
+func (c CargoCost) Deci() float64 {
+	if c.BilledDeci > 0 {
+		return c.BilledDeci
+	}
+	return c.DeclaredDeci
+}
+
+// CostPerDeci returns the amount paid per deci, zero when no deci is known
+func (c CargoCost) CostPerDeci() float64 {
+	if c.Deci() == 0 {
+		return 0
+	}
+	return roundCents(c.Amount / c.Deci())
+}
+
+// OverBilled reports whether the package was billed at a higher deci than
+// declared
+func (c CargoCost) OverBilled() bool {
+	return c.DeclaredDeci > 0 && c.BilledDeci-c.DeclaredDeci > 0.005
+}
+
+// CargoCostGroup sums the packages of one provider or city
+type CargoCostGroup struct {
+	Key      string
+	Packages int
+	Amount   float64
+	Deci     float64
+}
+
+// CostPerDeci returns the average amount paid per deci
+func (g CargoCostGroup) CostPerDeci() float64 {
+	if g.Deci == 0 {
+		return 0
+	}
+	return roundCents(g.Amount / g.Deci)
+}
+
+func (g *CargoCostGroup) add(c CargoCost) {
+	g.Packages++
+	g.Amount = roundCents(g.Amount + c.Amount)
+	g.Deci += c.Deci()
+}
+
+// CargoCostReport is the result of AnalyzeCargoCosts. Groups are sorted by
+// amount, highest first.
+type CargoCostReport struct {
+	Start      time.Time
+	End        time.Time
+	Invoices   []string    // deduction invoice serial numbers in the range
+	Missing    []string    // invoices whose details were not found
+	Packages   []CargoCost // sorted by invoice and package ID
+	Total      CargoCostGroup
+	ByProvider []CargoCostGroup
+	ByCity     []CargoCostGroup
+}
+
+// OverBilled returns the packages billed at a higher deci than declared
+func (r *CargoCostReport) OverBilled() []CargoCost {
+	var out []CargoCost
+	for _, c := range r.Packages {
+		if c.OverBilled() {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// AnalyzeCargoCosts collects the DeductionInvoices other financial records
+// between start and end, whose IDs are the invoice serial numbers, fetches
+// the package details of every invoice and joins them with the packages' declared deci, provider and
+// shipment city. Deduction invoices without cargo details are listed in
+// Missing. Packages whose order cannot be found are reported with OrderFound
+// false and the provider named on the invoice.
+func AnalyzeCargoCosts(ctx context.Context, finance FinanceService, orders OrderService, start, end time.Time) (*CargoCostReport, error) {
+	report := &CargoCostReport{Start: start, End: end}
+	seen := map[string]bool{}
+	q := OtherFinancialQuery{StartDate: start, EndDate: end, TransactionTypes: []string{FinancialDeductionInvoices}}
+	err := finance.ForEachOtherFinancial(ctx, q, func(f OtherFinancial) error {
+		if f.TransactionType == FinancialDeductionInvoices && f.ID != "" && !seen[f.ID] {
+			seen[f.ID] = true
+			report.Invoices = append(report.Invoices, f.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch deduction invoices: %w", err)
+	}
+	sort.Strings(report.Invoices)
+
+	for _, serial := range report.Invoices {
+		details, err := finance.GetCargoInvoiceDetails(ctx, serial)
+		if errors.Is(err, ErrNotFound) {
+			// Not every deduction invoice is a cargo invoice
+			report.Missing = append(report.Missing, serial)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch cargo invoice %s: %w", serial, err)
+		}
+		for _, d := range details {
+			report.Packages = append(report.Packages, CargoCost{
+				InvoiceSerialNumber: serial,
+				OrderNumber:         d.OrderNumber,
+				PackageID:           d.ShipmentPackageID,
+				Provider:            d.CargoProviderName,
+				Amount:              d.CargoAmount,
+				BilledDeci:          d.Deci,
+			})
+		}
+	}
+
+	packages, err := lookupPackages(ctx, orders, report.Packages)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := map[string]*CargoCostGroup{}
+	cities := map[string]*CargoCostGroup{}
+	for i := range report.Packages {
+		c := &report.Packages[i]
+		if o, ok := packages[c.PackageID]; ok {
+			c.OrderFound = true
+			c.DeclaredDeci = o.CargoDeci
+			if o.CargoProviderName != "" {
+				c.Provider = o.CargoProviderName
+			}
+			if o.ShipmentAddress != nil {
+				c.City = o.ShipmentAddress.City
+			}
+		}
+		report.Total.add(*c)
+		addCargoGroup(providers, c.Provider, *c)
+		addCargoGroup(cities, c.City, *c)
+	}
+	sort.SliceStable(report.Packages, func(i, j int) bool {
+		a, b := report.Packages[i], report.Packages[j]
+		if a.InvoiceSerialNumber != b.InvoiceSerialNumber {
+			return a.InvoiceSerialNumber < b.InvoiceSerialNumber
+		}
+		return a.PackageID < b.PackageID
+	})
+	report.ByProvider = sortedCargoGroups(providers)
+	report.ByCity = sortedCargoGroups(cities)
+	return report, nil
+}
+
+// lookupPackages loads the orders of the invoiced packages by package ID, then
+// by order number for packages the ID lookup did not return
+func lookupPackages(ctx context.Context, orders OrderService, costs []CargoCost) (map[int64]Order, error) {
+	packages := map[int64]Order{}
+	collect := func(o Order) error {
+		packages[o.ID] = o
+		return nil
+	}
+
+	var ids []int64
+	for _, c := range costs {
+		if c.PackageID != 0 {
+			ids = append(ids, c.PackageID)
+		}
+	}
+	for _, chunk := range splitChunks(ids, cargoLookupSize) {
+		opts := ListOrdersOptions{ShipmentPackageIDs: chunk, Size: cargoLookupSize}
+		if err := orders.ForEach(ctx, opts, collect); err != nil {
+			return nil, fmt.Errorf("failed to fetch orders: %w", err)
+		}
+	}
+
+	looked := map[string]bool{}
+	for _, c := range costs {
+		if _, ok := packages[c.PackageID]; ok || c.OrderNumber == "" || looked[c.OrderNumber] {
+			continue
+		}
+		looked[c.OrderNumber] = true
+		opts := ListOrdersOptions{OrderNumber: c.OrderNumber, Size: cargoLookupSize}
+		if err := orders.ForEach(ctx, opts, collect); err != nil {
+			return nil, fmt.Errorf("failed to fetch order %s: %w", c.OrderNumber, err)
+		}
+	}
+	return packages, nil
+}
+
+func addCargoGroup(groups map[string]*CargoCostGroup, key string, c CargoCost) {
+	g, ok := groups[key]
+	if !ok {
+		g = &CargoCostGroup{Key: key}
+		groups[key] = g
+	}
+	g.add(c)
+}
+
+func sortedCargoGroups(groups map[string]*CargoCostGroup) []CargoCostGroup {
+	out := make([]CargoCostGroup, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Amount != out[j].Amount {
+			return out[i].Amount > out[j].Amount
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
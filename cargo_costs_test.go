@@ -0,0 +1,92 @@
+package trendyol_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestAnalyzeCargoCosts kargo faturalarının kesinti faturası kayıtlarından
+// bulunduğunu, kargo detayı olmayan faturaların atlandığını, paket
+// bilgileriyle birleştirilip firma ve şehre göre toplandığını ve beyan
+// edilenden yüksek desiden faturalanan paketlerin işaretlendiğini doğrular.
+func TestAnalyzeCargoCosts(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	addr := func(city string) *trendyol.OrderAddress { return &trendyol.OrderAddress{City: city} }
+	p1 := srv.AddOrder(trendyol.Order{OrderNumber: "K-1", ShipmentAddress: addr("İstanbul")})
+	p2 := srv.AddOrder(trendyol.Order{OrderNumber: "K-2", ShipmentAddress: addr("Ankara")})
+	p3 := srv.AddOrder(trendyol.Order{OrderNumber: "K-3", ShipmentAddress: addr("İstanbul"), CargoProviderName: "Aras Kargo Marketplace", CargoDeci: 4})
+	if err := client.Orders.UpdateBoxInfo(ctx, p1.ID, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Orders.UpdateBoxInfo(ctx, p2.ID, 1, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 30)
+	srv.AddOtherFinancials(
+		trendyol.OtherFinancial{ID: "CI-2", TransactionDate: start.AddDate(0, 0, 2).UnixMilli(), TransactionType: trendyol.FinancialDeductionInvoices, Debt: 70},
+		trendyol.OtherFinancial{ID: "CI-1", TransactionDate: start.AddDate(0, 0, 3).UnixMilli(), TransactionType: trendyol.FinancialDeductionInvoices, Debt: 130},
+		trendyol.OtherFinancial{ID: "CI-1", TransactionDate: start.AddDate(0, 0, 20).UnixMilli(), TransactionType: trendyol.FinancialDeductionInvoices, Debt: 130},
+		trendyol.OtherFinancial{ID: "CA-1", TransactionDate: start.AddDate(0, 0, 21).UnixMilli(), TransactionType: trendyol.FinancialCashAdvance, Credit: 100},
+		trendyol.OtherFinancial{ID: "AD-1", TransactionDate: start.AddDate(0, 0, 22).UnixMilli(), TransactionType: trendyol.FinancialDeductionInvoices, Debt: 25},
+	)
+	const tex = "Trendyol Express Marketplace"
+	srv.SetCargoInvoice("CI-1", []trendyol.CargoInvoiceDetail{
+		{OrderNumber: "K-2", ShipmentPackageID: p2.ID, CargoAmount: 90, CargoProviderName: tex, Deci: 5},
+		{OrderNumber: "K-1", ShipmentPackageID: p1.ID, CargoAmount: 40, CargoProviderName: tex, Deci: 2},
+	})
+	srv.SetCargoInvoice("CI-2", []trendyol.CargoInvoiceDetail{
+		{OrderNumber: "K-3", ShipmentPackageID: p3.ID, CargoAmount: 60, CargoProviderName: "Aras", Deci: 4},
+		{OrderNumber: "K-404", ShipmentPackageID: 404, CargoAmount: 10, CargoProviderName: "Yurtiçi Kargo", Deci: 1},
+	})
+
+	report, err := trendyol.AnalyzeCargoCosts(ctx, client.Finance, client.Orders, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Invoices) != 3 || report.Invoices[0] != "AD-1" || len(report.Packages) != 4 {
+		t.Fatalf("üç kesinti faturası ve dört paket beklendi: %v, %d", report.Invoices, len(report.Packages))
+	}
+	if len(report.Missing) != 1 || report.Missing[0] != "AD-1" {
+		t.Errorf("kargo detayı olmayan fatura atlanmalıydı: %v", report.Missing)
+	}
+	if calls := srv.Calls(trendyol.EndpointGetCargoInvoiceDetailsKey); len(calls) != 3 {
+		t.Errorf("yalnızca kesinti faturaları sorgulanmalıydı, %d istek atıldı", len(calls))
+	}
+	if calls := srv.Calls(trendyol.EndpointGetSettlementsKey); len(calls) != 0 {
+		t.Errorf("hakediş uç noktası kullanılmamalıydı, %d istek atıldı", len(calls))
+	}
+	if report.Total.Amount != 200 || report.Total.Deci != 12 {
+		t.Errorf("toplam beklenmedik: %+v", report.Total)
+	}
+
+	top := report.ByProvider[0]
+	if top.Key != tex || top.Packages != 2 || top.Amount != 130 || top.CostPerDeci() != 18.57 {
+		t.Errorf("firma özeti beklenmedik: %+v", report.ByProvider)
+	}
+	if p := report.ByProvider[1]; p.Key != "Aras Kargo Marketplace" || p.Amount != 60 {
+		t.Errorf("sipariş üzerindeki kargo firması kullanılmalıydı: %+v", report.ByProvider)
+	}
+	if c := report.ByCity[0]; c.Key != "İstanbul" || c.Amount != 100 || c.Deci != 6 {
+		t.Errorf("şehir özeti beklenmedik: %+v", report.ByCity)
+	}
+
+	over := report.OverBilled()
+	if len(over) != 1 || over[0].PackageID != p2.ID || over[0].DeclaredDeci != 3 || over[0].BilledDeci != 5 {
+		t.Errorf("yalnızca K-2 yüksek desiden faturalanmış olmalıydı: %+v", over)
+	}
+	for _, c := range report.Packages {
+		if c.PackageID == 404 && (c.OrderFound || c.Provider != "Yurtiçi Kargo") {
+			t.Errorf("bulunamayan paket faturadaki firmayla raporlanmalıydı: %+v", c)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package trendyol
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CatalogStore holds a local copy of the product catalog
+type CatalogStore interface {
+	Get(ctx context.Context, barcode string) (Product, bool, error)
+	// All returns every stored product sorted by barcode
+	All(ctx context.Context) ([]Product, error)
+	// LastSync returns the time of the last committed sync, zero if none
+	LastSync(ctx context.Context) (time.Time, error)
+	// Commit stores changed products, drops removed barcodes and records the
+	// sync time in one step
+	Commit(ctx context.Context, put []Product, remove []string, syncedAt time.Time) error
+}
+
+// catalogData is the state kept by the built-in stores
+type catalogData struct {
+	LastSync time.Time          `json:"lastSync"`
+	Products map[string]Product `json:"products"`
+}
+
+func (d *catalogData) apply(put []Product, remove []string, syncedAt time.Time) {
+	if d.Products == nil {
+		d.Products = map[string]Product{}
+	}
+	for _, bc := range remove {
+		delete(d.Products, bc)
+	}
+	for _, p := range put {
+		d.Products[p.Barcode] = p
+	}
+	d.LastSync = syncedAt
+}
+
+func (d *catalogData) all() []Product {
+	out := make([]Product, 0, len(d.Products))
+	for _, p := range d.Products {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Barcode < out[j].Barcode })
+	return out
+}
+
+// memoryCatalogStore keeps the catalog in memory
+type memoryCatalogStore struct {
+	mu   sync.Mutex
+	data catalogData
+}
+
+// NewMemoryCatalogStore returns a CatalogStore that lives as long as the process
+func NewMemoryCatalogStore() CatalogStore {
+	return &memoryCatalogStore{}
+}
+
+func (s *memoryCatalogStore) Get(ctx context.Context, barcode string) (Product, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.data.Products[barcode]
+	return p, ok, nil
+}
+
+func (s *memoryCatalogStore) All(ctx context.Context) ([]Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.all(), nil
+}
+
+func (s *memoryCatalogStore) LastSync(ctx context.Context) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.LastSync, nil
+}
+
+func (s *memoryCatalogStore) Commit(ctx context.Context, put []Product, remove []string, syncedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.apply(put, remove, syncedAt)
+	return nil
+}
+
+// fileCatalogStore keeps the catalog in a JSON file, loaded on first use
+type fileCatalogStore struct {
+	path   string
+	mu     sync.Mutex
+	data   catalogData
+	loaded bool
+}
+
+// NewFileCatalogStore returns a CatalogStore backed by a JSON file. A missing
+// file is treated as an empty catalog; the file is rewritten on every Commit.
+func NewFileCatalogStore(path string) CatalogStore {
+	return &fileCatalogStore{path: path}
+}
+
+// load reads the file once; callers hold s.mu
+func (s *fileCatalogStore) load() error {
+	if s.loaded {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &s.data); err != nil {
+			return fmt.Errorf("failed to decode catalog %s: %w", s.path, err)
+		}
+	}
+	s.loaded = true
+	return nil
+}
+
+func (s *fileCatalogStore) Get(ctx context.Context, barcode string) (Product, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return Product{}, false, err
+	}
+	p, ok := s.data.Products[barcode]
+	return p, ok, nil
+}
+
+func (s *fileCatalogStore) All(ctx context.Context) ([]Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s.data.all(), nil
+}
+
+func (s *fileCatalogStore) LastSync(ctx context.Context) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return time.Time{}, err
+	}
+	return s.data.LastSync, nil
+}
+
+func (s *fileCatalogStore) Commit(ctx context.Context, put []Product, remove []string, syncedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	next := catalogData{LastSync: s.data.LastSync, Products: make(map[string]Product, len(s.data.Products)+len(put))}
+	for bc, p := range s.data.Products {
+		next.Products[bc] = p
+	}
+	next.apply(put, remove, syncedAt)
+	if err := writeFileAtomic(s.path, next); err != nil {
+		return err
+	}
+	s.data = next
+	return nil
+}
+
+// CatalogSyncReport summarises a sync run. A product is listed under at most
+// one of Added, Archived, Rejected and Changed, checked in that order.
+type CatalogSyncReport struct {
+	Full     bool
+	Since    time.Time // start of the modification window, zero for a full crawl
+	Until    time.Time
+	Seen     int
+	Added    []string
+	Changed  []string
+	Archived []string // newly archived
+	Rejected []string // newly rejected
+	Removed  []string // stored but no longer listed, full crawls only
+}
+
+// CatalogOption configures a CatalogSyncer
+type CatalogOption func(*CatalogSyncer)
+
+// WithCatalogPageSize sets the page size used while crawling, default 100
+func WithCatalogPageSize(size int) CatalogOption {
+	return func(s *CatalogSyncer) {
+		s.pageSize = size
+	}
+}
+
+// WithCatalogOverlap sets how far an incremental sync reaches back before the
+// last sync to catch late modification dates, default 5 minutes
+func WithCatalogOverlap(d time.Duration) CatalogOption {
+	return func(s *CatalogSyncer) {
+		s.overlap = d
+	}
+}
+
+// WithCatalogClock sets the time source, mainly for tests
+func WithCatalogClock(now func() time.Time) CatalogOption {
+	return func(s *CatalogSyncer) {
+		s.now = now
+	}
+}
+
+// CatalogSyncer mirrors the product catalog into a CatalogStore
+type CatalogSyncer struct {
+	products ProductService
+	store    CatalogStore
+	pageSize int
+	overlap  time.Duration
+	now      func() time.Time
+}
+
+// NewCatalogSyncer creates a syncer reading from products, usually client.Products
+func NewCatalogSyncer(products ProductService, store CatalogStore, opts ...CatalogOption) *CatalogSyncer {
+	s := &CatalogSyncer{
+		products: products,
+		store:    store,
+		pageSize: 100,
+		overlap:  5 * time.Minute,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// FullSync crawls the whole catalog and replaces the stored copy
+func (s *CatalogSyncer) FullSync(ctx context.Context) (*CatalogSyncReport, error) {
+	return s.sync(ctx, time.Time{})
+}
+
+// Sync fetches products modified since the last sync, or crawls the whole
+// catalog when the store has never been synced
+func (s *CatalogSyncer) Sync(ctx context.Context) (*CatalogSyncReport, error) {
+	last, err := s.store.LastSync(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read last sync time: %w", err)
+	}
+	if last.IsZero() {
+		return s.sync(ctx, time.Time{})
+	}
+	return s.sync(ctx, last.Add(-s.overlap))
+}
+
+func (s *CatalogSyncer) sync(ctx context.Context, since time.Time) (*CatalogSyncReport, error) {
+	until := s.now()
+	report := &CatalogSyncReport{Full: since.IsZero(), Since: since, Until: until}
+
+	var opts *ProductListOptions
+	if !report.Full {
+		opts = &ProductListOptions{StartDate: &since, EndDate: &until, DateQueryType: DateQueryLastModified}
+	}
+
+	var put []Product
+	seen := map[string]bool{}
+	err := s.products.ForEach(ctx, s.pageSize, opts, func(p Product) error {
+		if seen[p.Barcode] {
+			return nil
+		}
+		seen[p.Barcode] = true
+		report.Seen++
+
+		old, ok, err := s.store.Get(ctx, p.Barcode)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !ok:
+			report.Added = append(report.Added, p.Barcode)
+		case p.Archived && !old.Archived:
+			report.Archived = append(report.Archived, p.Barcode)
+		case p.Rejected && !old.Rejected:
+			report.Rejected = append(report.Rejected, p.Barcode)
+		case !sameProduct(old, p):
+			report.Changed = append(report.Changed, p.Barcode)
+		default:
+			return nil
+		}
+		put = append(put, p)
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	if report.Full {
+		stored, err := s.store.All(ctx)
+		if err != nil {
+			return report, err
+		}
+		for _, p := range stored {
+			if !seen[p.Barcode] {
+				report.Removed = append(report.Removed, p.Barcode)
+			}
+		}
+	}
+
+	if err := s.store.Commit(ctx, put, report.Removed, until); err != nil {
+		return report, fmt.Errorf("failed to commit catalog: %w", err)
+	}
+	return report, nil
+}
+
+// sameProduct compares products by their JSON form, so that a copy read back
+// from a file store equals the listed product
+func sameProduct(a, b Product) bool {
+	x, errA := json.Marshal(a)
+	y, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(x, y)
+}
@@ -0,0 +1,214 @@
+package trendyol
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Catalog change kinds
+const (
+	CatalogChangeCreate     = "CREATE"
+	CatalogChangeContent    = "CONTENT"
+	CatalogChangePriceStock = "PRICE_STOCK"
+	CatalogChangeArchive    = "ARCHIVE"
+	CatalogChangeUnarchive  = "UNARCHIVE"
+)
+
+// CatalogChange describes why a barcode appears in a CatalogDiff
+type CatalogChange struct {
+	Barcode string
+	Kind    string
+	Fields  []string // changed fields for CONTENT and PRICE_STOCK
+}
+
+func (c CatalogChange) String() string {
+	if len(c.Fields) == 0 {
+		return c.Kind + " " + c.Barcode
+	}
+	return fmt.Sprintf("%s %s (%s)", c.Kind, c.Barcode, strings.Join(c.Fields, ", "))
+}
+
+// CatalogDiff is the minimal set of writes that turns the current catalog
+// into the desired one. A product can need both a content update and a
+// price/stock update, since Products.Update does not change price or stock.
+type CatalogDiff struct {
+	Create         []Product            // for Products.Create
+	Update         []Product            // content changes, for Products.Update
+	PriceInventory []PriceInventoryItem // for PriceInventory.Update
+	Archive        []ArchiveStateItem   // for Products.UpdateArchiveState
+	Changes        []CatalogChange      // one entry per change, in barcode order
+	Unchanged      int
+}
+
+// CreateBatches splits Create into batches of at most MaxProductBatchSize
+func (d *CatalogDiff) CreateBatches() [][]Product {
+	return splitChunks(d.Create, MaxProductBatchSize)
+}
+
+// UpdateBatches splits Update into batches of at most MaxProductBatchSize
+func (d *CatalogDiff) UpdateBatches() [][]Product {
+	return splitChunks(d.Update, MaxProductBatchSize)
+}
+
+// ArchiveBatches splits Archive into batches of at most MaxProductBatchSize
+func (d *CatalogDiff) ArchiveBatches() [][]ArchiveStateItem {
+	return splitChunks(d.Archive, MaxProductBatchSize)
+}
+
+// PriceInventoryBatches splits PriceInventory into batches of at most MaxPriceInventoryBatchSize
+func (d *CatalogDiff) PriceInventoryBatches() [][]PriceInventoryItem {
+	return splitChunks(d.PriceInventory, MaxPriceInventoryBatchSize)
+}
+
+// DiffCatalog compares the desired catalog, e.g. an ERP export, with the
+// current Trendyol state, e.g. from a CatalogStore, and classifies every
+// barcode. Only seller-controlled fields are compared; server fields such as
+// Approved or LastUpdateDate in current are ignored. Current products that
+// are missing from desired and not yet archived are archived, archived
+// products that are desired again are unarchived; both are listed in Archive.
+func DiffCatalog(desired, current []Product) *CatalogDiff {
+	byBarcode := make(map[string]Product, len(current))
+	for _, p := range current {
+		byBarcode[p.Barcode] = p
+	}
+
+	d := &CatalogDiff{}
+	wanted := make(map[string]bool, len(desired))
+	sorted := append([]Product(nil), desired...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Barcode < sorted[j].Barcode })
+	for _, p := range sorted {
+		if wanted[p.Barcode] {
+			continue
+		}
+		wanted[p.Barcode] = true
+
+		cur, ok := byBarcode[p.Barcode]
+		if !ok {
+			d.Create = append(d.Create, p)
+			d.Changes = append(d.Changes, CatalogChange{Barcode: p.Barcode, Kind: CatalogChangeCreate})
+			continue
+		}
+
+		unarchive := cur.Archived
+		if unarchive {
+			d.Archive = append(d.Archive, ArchiveStateItem{Barcode: p.Barcode, Archived: false})
+			d.Changes = append(d.Changes, CatalogChange{Barcode: p.Barcode, Kind: CatalogChangeUnarchive})
+		}
+		content := contentChanges(p, cur)
+		if len(content) > 0 {
+			d.Update = append(d.Update, p)
+			d.Changes = append(d.Changes, CatalogChange{Barcode: p.Barcode, Kind: CatalogChangeContent, Fields: content})
+		}
+		priceStock := priceStockChanges(p, cur)
+		if len(priceStock) > 0 {
+			d.PriceInventory = append(d.PriceInventory, PriceInventoryItem{
+				Barcode:   p.Barcode,
+				Quantity:  p.Quantity,
+				SalePrice: p.SalePrice,
+				ListPrice: p.ListPrice,
+			})
+			d.Changes = append(d.Changes, CatalogChange{Barcode: p.Barcode, Kind: CatalogChangePriceStock, Fields: priceStock})
+		}
+		if !unarchive && len(content) == 0 && len(priceStock) == 0 {
+			d.Unchanged++
+		}
+	}
+
+	var archive []string
+	for _, p := range current {
+		if !wanted[p.Barcode] && !p.Archived {
+			archive = append(archive, p.Barcode)
+		}
+	}
+	sort.Strings(archive)
+	for _, bc := range archive {
+		d.Archive = append(d.Archive, ArchiveStateItem{Barcode: bc, Archived: true})
+		d.Changes = append(d.Changes, CatalogChange{Barcode: bc, Kind: CatalogChangeArchive})
+	}
+	return d
+}
+
+// contentChanges lists the content fields of want that differ from have
+func contentChanges(want, have Product) []string {
+	var fields []string
+	add := func(changed bool, field string) {
+		if changed {
+			fields = append(fields, field)
+		}
+	}
+	add(want.Title != have.Title, "title")
+	add(want.Description != have.Description, "description")
+	add(!sameImages(want.Images, have.Images), "images")
+	add(!sameAttributes(want.Attributes, have.Attributes), "attributes")
+	add(want.ProductMainID != have.ProductMainID, "productMainId")
+	add(want.BrandID != have.BrandID, "brandId")
+	add(want.CategoryID != have.CategoryID, "categoryId")
+	add(want.StockCode != have.StockCode, "stockCode")
+	add(want.VATRate != have.VATRate, "vatRate")
+	add(!samePrice(want.DimensionalWeight, have.DimensionalWeight), "dimensionalWeight")
+	return fields
+}
+
+// priceStockChanges lists the price and stock fields of want that differ from have
+func priceStockChanges(want, have Product) []string {
+	var fields []string
+	if want.Quantity != have.Quantity {
+		fields = append(fields, "quantity")
+	}
+	if !samePrice(want.SalePrice, have.SalePrice) {
+		fields = append(fields, "salePrice")
+	}
+	if !samePrice(want.ListPrice, have.ListPrice) {
+		fields = append(fields, "listPrice")
+	}
+	return fields
+}
+
+func samePrice(a, b float64) bool {
+	return math.Abs(a-b) < 0.005
+}
+
+// sameImages compares image URLs in order, the first image being the main one
+func sameImages(a, b []ProductImage) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].URL != b[i].URL {
+			return false
+		}
+	}
+	return true
+}
+
+// sameAttributes compares attributes regardless of order. Values are matched
+// by ID when set, otherwise by the custom or listed text value.
+func sameAttributes(a, b []ProductAttribute) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	key := func(attrs []ProductAttribute) []string {
+		keys := make([]string, len(attrs))
+		for i, at := range attrs {
+			value := at.CustomAttributeValue
+			if value == "" {
+				value = at.AttributeValue
+			}
+			if at.AttributeValueID != 0 {
+				value = fmt.Sprintf("#%d", at.AttributeValueID)
+			}
+			keys[i] = fmt.Sprintf("%d=%s", at.AttributeID, value)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	ka, kb := key(a), key(b)
+	for i := range ka {
+		if ka[i] != kb[i] {
+			return false
+		}
+	}
+	return true
+}
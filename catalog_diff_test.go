@@ -0,0 +1,124 @@
+package trendyol_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestDiffCatalog değişikliklerin içerik, fiyat/stok, oluşturma ve arşiv
+// olarak sınıflandırıldığını ve yalnızca gerekli kalemlerin üretildiğini doğrular.
+func TestDiffCatalog(t *testing.T) {
+	current := []trendyol.Product{
+		fixtureProduct("D-1"),
+		fixtureProduct("D-2"),
+		fixtureProduct("D-3"),
+		fixtureProduct("D-4"),
+		fixtureProduct("D-OLD"),
+	}
+	archived := fixtureProduct("D-ARCH")
+	archived.Archived = true
+	back := fixtureProduct("D-BACK")
+	back.Archived = true
+	current = append(current, archived, back)
+	// Listing returns server fields and attribute names that the ERP export lacks
+	for i := range current {
+		current[i].Approved = true
+		current[i].LastUpdateDate = 1700000000000
+		current[i].Attributes[0].AttributeName = "Menşei"
+	}
+
+	unchanged := fixtureProduct("D-1")
+	content := fixtureProduct("D-2")
+	content.Title = "Yeni Başlık"
+	content.Images = append(content.Images, trendyol.ProductImage{URL: "https://example.com/2.jpg"})
+	price := fixtureProduct("D-3")
+	price.SalePrice = 129.90
+	price.Quantity = 0
+	both := fixtureProduct("D-4")
+	both.Description = "Yeni açıklama"
+	both.ListPrice = 299.90
+	created := fixtureProduct("D-NEW")
+
+	diff := trendyol.DiffCatalog([]trendyol.Product{created, both, price, content, unchanged, fixtureProduct("D-BACK")}, current)
+
+	if len(diff.Create) != 1 || diff.Create[0].Barcode != "D-NEW" {
+		t.Errorf("D-NEW oluşturulmalıydı: %+v", diff.Create)
+	}
+	if len(diff.Update) != 2 || diff.Update[0].Barcode != "D-2" || diff.Update[1].Barcode != "D-4" {
+		t.Errorf("D-2 ve D-4 içerik güncellemesi almalıydı: %+v", diff.Update)
+	}
+	if len(diff.PriceInventory) != 2 || diff.PriceInventory[0] != (trendyol.PriceInventoryItem{Barcode: "D-3", Quantity: 0, SalePrice: 129.90, ListPrice: 249.90}) {
+		t.Errorf("beklenmeyen fiyat/stok kalemleri: %+v", diff.PriceInventory)
+	}
+	if fmt.Sprint(diff.Archive) != "[{D-BACK false} {D-OLD true}]" || diff.Unchanged != 1 {
+		t.Errorf("D-OLD arşivlenmeli, D-BACK arşivden çıkmalı, D-1 değişmemeliydi: %v %d", diff.Archive, diff.Unchanged)
+	}
+
+	want := []string{
+		"CONTENT D-2 (title, images)",
+		"PRICE_STOCK D-3 (quantity, salePrice)",
+		"CONTENT D-4 (description)",
+		"PRICE_STOCK D-4 (listPrice)",
+		"UNARCHIVE D-BACK",
+		"CREATE D-NEW",
+		"ARCHIVE D-OLD",
+	}
+	if len(diff.Changes) != len(want) {
+		t.Fatalf("beklenmeyen değişiklikler: %v", diff.Changes)
+	}
+	for i, c := range diff.Changes {
+		if c.String() != want[i] {
+			t.Errorf("değişiklik %d = %q, beklenen %q", i, c, want[i])
+		}
+	}
+}
+
+// TestCatalogDiffBatches kalemlerin uç nokta sınırlarına göre bölündüğünü doğrular.
+func TestCatalogDiffBatches(t *testing.T) {
+	var desired []trendyol.Product
+	for i := 0; i < 1201; i++ {
+		desired = append(desired, fixtureProduct(fmt.Sprintf("B-%04d", i)))
+	}
+	diff := trendyol.DiffCatalog(desired, nil)
+	batches := diff.CreateBatches()
+	if len(batches) != 3 || len(batches[0]) != trendyol.MaxProductBatchSize || len(batches[2]) != 201 {
+		t.Fatalf("1201 ürün 500+500+201 olarak bölünmeliydi: %d", len(batches))
+	}
+	if len(diff.UpdateBatches()) != 0 || len(diff.PriceInventoryBatches()) != 0 {
+		t.Error("boş listeler için batch üretilmemeli")
+	}
+}
+
+// TestCatalogDiffArchiveApply arşiv değişikliklerinin arşiv durumu uç noktası
+// üzerinden parçalı olarak uygulandığını doğrular.
+func TestCatalogDiffArchiveApply(t *testing.T) {
+	srv := trendyoltest.NewServer(trendyoltest.WithBatchPolls(0))
+	defer srv.Close()
+	client := srv.Client()
+
+	back := fixtureProduct("A-BACK")
+	back.Archived = true
+	srv.AddProduct(back)
+	srv.AddProduct(fixtureProduct("A-OLD"))
+	current := []trendyol.Product{back, fixtureProduct("A-OLD")}
+
+	diff := trendyol.DiffCatalog([]trendyol.Product{fixtureProduct("A-BACK")}, current)
+	res, err := client.Products.UpdateArchiveStateChunked(context.Background(), diff.Archive,
+		&trendyol.ChunkOptions{Wait: true, WaitOptions: fastWait})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Batches) != 1 || res.Items["A-OLD"].Status != trendyol.BatchItemStatusSuccess {
+		t.Errorf("tek batch ve başarılı kalemler beklendi: %+v", res)
+	}
+	if p, _ := srv.Product("A-BACK"); p.Archived {
+		t.Error("A-BACK arşivden çıkmalıydı")
+	}
+	if p, _ := srv.Product("A-OLD"); !p.Archived {
+		t.Error("A-OLD arşivlenmeliydi")
+	}
+}
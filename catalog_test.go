@@ -0,0 +1,104 @@
+package trendyol_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestCatalogSyncer tam taramanın ardından artımlı senkronizasyonun yalnızca
+// değişen ürünleri çekip eklenen, değişen, arşivlenen ve reddedilenleri
+// raporladığını doğrular.
+func TestCatalogSyncer(t *testing.T) {
+	clock := &testClock{now: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)}
+	srv := trendyoltest.NewServer(trendyoltest.WithClock(clock.Now))
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	for i := 1; i <= 5; i++ {
+		srv.AddProduct(fixtureProduct(fmt.Sprintf("CAT-%d", i)))
+	}
+	clock.Advance(time.Hour)
+
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	syncer := trendyol.NewCatalogSyncer(client.Products, trendyol.NewFileCatalogStore(path),
+		trendyol.WithCatalogPageSize(2), trendyol.WithCatalogClock(clock.Now))
+
+	report, err := syncer.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Full || len(report.Added) != 5 {
+		t.Fatalf("ilk senkronizasyon tam tarama olmalıydı: %+v", report)
+	}
+
+	clock.Advance(time.Hour)
+	changed, _ := srv.Product("CAT-1")
+	changed.Title = "Pamuk Hoodie V2"
+	srv.AddProduct(changed)
+	archived, _ := srv.Product("CAT-2")
+	archived.Archived = true
+	srv.AddProduct(archived)
+	rejected, _ := srv.Product("CAT-3")
+	rejected.Rejected = true
+	srv.AddProduct(rejected)
+	srv.AddProduct(fixtureProduct("CAT-6"))
+	clock.Advance(time.Minute)
+
+	// A fresh syncer on the same file continues incrementally
+	syncer = trendyol.NewCatalogSyncer(client.Products, trendyol.NewFileCatalogStore(path),
+		trendyol.WithCatalogPageSize(2), trendyol.WithCatalogClock(clock.Now))
+	report, err = syncer.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Full || report.Seen != 4 {
+		t.Fatalf("yalnızca değişen 4 ürün çekilmeliydi: %+v", report)
+	}
+	got := fmt.Sprint(report.Added, report.Changed, report.Archived, report.Rejected)
+	if got != "[CAT-6] [CAT-1] [CAT-2] [CAT-3]" {
+		t.Fatalf("beklenmeyen rapor: %s", got)
+	}
+	for _, c := range srv.Calls(trendyol.EndpointGetProductsKey)[3:] {
+		if c.Query.Get("dateQueryType") != trendyol.DateQueryLastModified || c.Query.Get("startDate") == "" {
+			t.Fatalf("artımlı istek tarih filtresi içermeliydi: %v", c.Query)
+		}
+	}
+
+	store := trendyol.NewFileCatalogStore(path)
+	p, ok, err := store.Get(ctx, "CAT-1")
+	if err != nil || !ok || p.Title != "Pamuk Hoodie V2" {
+		t.Fatalf("dosyadaki kopya güncellenmeliydi: %+v %v", p, err)
+	}
+}
+
+// TestCatalogFullSyncRemoved tam taramada artık listelenmeyen ürünlerin
+// depodan silinip raporlandığını doğrular.
+func TestCatalogFullSyncRemoved(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(fixtureProduct("CAT-A"))
+	ctx := context.Background()
+
+	store := trendyol.NewMemoryCatalogStore()
+	if err := store.Commit(ctx, []trendyol.Product{fixtureProduct("CAT-GONE")}, nil, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	report, err := trendyol.NewCatalogSyncer(srv.Client().Products, store).FullSync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(report.Added, report.Removed) != "[CAT-A] [CAT-GONE]" {
+		t.Fatalf("beklenmeyen rapor: %+v", report)
+	}
+	all, _ := store.All(ctx)
+	if len(all) != 1 || all[0].Barcode != "CAT-A" {
+		t.Fatalf("depoda yalnızca CAT-A kalmalıydı: %+v", all)
+	}
+}
@@ -0,0 +1,179 @@
+package trendyol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Maximum number of items Trendyol accepts in a single batch request
+const (
+	MaxProductBatchSize        = 500
+	MaxPriceInventoryBatchSize = 1000
+)
+
+// ChunkOptions configures chunked batch submission
+type ChunkOptions struct {
+	ChunkSize   int          // items per batch request, capped at the endpoint maximum
+	Concurrency int          // batch requests submitted in parallel, default 2
+	Wait        bool         // wait for every batch to complete and record item statuses
+	WaitOptions *WaitOptions // polling options used when Wait is set
+}
+
+// ChunkBatch describes one submitted chunk
+type ChunkBatch struct {
+	BatchRequestID string
+	Barcodes       []string
+	Result         *BatchResult // set when ChunkOptions.Wait is true
+	Err            error
+}
+
+// ChunkItem is the outcome of a single barcode in a chunked submission
+type ChunkItem struct {
+	BatchRequestID string
+	Status         string // item status once the batch completed, empty otherwise
+	FailureReasons []string
+	Err            error // submission or polling error of the item's chunk
+}
+
+// ChunkedResult aggregates the batches of a chunked submission
+type ChunkedResult struct {
+	Batches []ChunkBatch
+	Items   map[string]ChunkItem // keyed by barcode
+}
+
+// Failed returns the barcodes whose chunk errored or whose item was rejected
+func (r *ChunkedResult) Failed() []string {
+	var out []string
+	for _, b := range r.Batches {
+		for _, bc := range b.Barcodes {
+			it := r.Items[bc]
+			if it.Err != nil || it.Status == BatchItemStatusFailed || len(it.FailureReasons) > 0 {
+				out = append(out, bc)
+			}
+		}
+	}
+	return out
+}
+
+// splitChunks splits items into consecutive slices of at most size items
+func splitChunks[T any](items []T, size int) [][]T {
+	var chunks [][]T
+	for start := 0; start < len(items); start += size {
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		chunks = append(chunks, items[start:end])
+	}
+	return chunks
+}
+
+// submitChunked splits items into chunks of at most maxSize, submits them with
+// bounded concurrency and optionally waits for each batch. Every submission
+// goes through Client.Do and therefore shares the client's rate limiter.
+func submitChunked[T any](
+	ctx context.Context,
+	items []T,
+	maxSize int,
+	opts *ChunkOptions,
+	barcode func(T) string,
+	submit func(context.Context, []T) (*BatchResponse, error),
+	wait func(context.Context, string, *WaitOptions) (*BatchResult, error),
+) (*ChunkedResult, error) {
+	var o ChunkOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.ChunkSize <= 0 || o.ChunkSize > maxSize {
+		o.ChunkSize = maxSize
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 2
+	}
+
+	chunks := splitChunks(items, o.ChunkSize)
+
+	result := &ChunkedResult{
+		Batches: make([]ChunkBatch, len(chunks)),
+		Items:   make(map[string]ChunkItem, len(items)),
+	}
+
+	sem := make(chan struct{}, o.Concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		batch := &result.Batches[i]
+		batch.Barcodes = make([]string, len(chunk))
+		for j, it := range chunk {
+			batch.Barcodes[j] = barcode(it)
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			batch.Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(chunk []T, batch *ChunkBatch) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := submit(ctx, chunk)
+			if err != nil {
+				batch.Err = err
+				return
+			}
+			batch.BatchRequestID = resp.BatchRequestID
+			if o.Wait {
+				batch.Result, batch.Err = wait(ctx, resp.BatchRequestID, o.WaitOptions)
+			}
+		}(chunk, batch)
+	}
+	wg.Wait()
+
+	var errs []error
+	for i := range result.Batches {
+		b := &result.Batches[i]
+		if b.Err != nil {
+			errs = append(errs, fmt.Errorf("chunk %d: %w", i, b.Err))
+		}
+		for _, bc := range b.Barcodes {
+			result.Items[bc] = ChunkItem{BatchRequestID: b.BatchRequestID, Err: b.Err}
+		}
+		if b.Result == nil {
+			continue
+		}
+		for _, it := range b.Result.Items {
+			item := result.Items[it.Barcode]
+			item.Status = it.Status
+			item.FailureReasons = it.FailureReasons
+			result.Items[it.Barcode] = item
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+func productBarcode(p Product) string { return p.Barcode }
+
+func (s *productService) CreateChunked(ctx context.Context, products []Product, opts *ChunkOptions) (*ChunkedResult, error) {
+	return submitChunked(ctx, products, MaxProductBatchSize, opts, productBarcode, s.Create, s.WaitForBatch)
+}
+
+func (s *productService) UpdateChunked(ctx context.Context, products []Product, opts *ChunkOptions) (*ChunkedResult, error) {
+	return submitChunked(ctx, products, MaxProductBatchSize, opts, productBarcode, s.Update, s.WaitForBatch)
+}
+
+func (s *productService) UpdateArchiveStateChunked(ctx context.Context, items []ArchiveStateItem, opts *ChunkOptions) (*ChunkedResult, error) {
+	return submitChunked(ctx, items, MaxProductBatchSize, opts,
+		func(it ArchiveStateItem) string { return it.Barcode },
+		s.UpdateArchiveState, s.WaitForBatch)
+}
+
+func (s *priceInventoryService) UpdateChunked(ctx context.Context, items []PriceInventoryItem, opts *ChunkOptions) (*ChunkedResult, error) {
+	return submitChunked(ctx, items, MaxPriceInventoryBatchSize, opts,
+		func(it PriceInventoryItem) string { return it.Barcode },
+		s.Update, s.client.Products.WaitForBatch)
+}
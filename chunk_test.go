@@ -0,0 +1,73 @@
+package trendyol_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestPriceInventoryUpdateChunked büyük listenin parçalara bölündüğünü ve her barkodun
+// batch ID'si ile nihai durumunun raporlandığını doğrular.
+func TestPriceInventoryUpdateChunked(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	var items []trendyol.PriceInventoryItem
+	for i := 0; i < 7; i++ {
+		bc := fmt.Sprintf("BC-%d", i)
+		srv.AddProduct(fixtureProduct(bc))
+		items = append(items, trendyol.PriceInventoryItem{Barcode: bc, Quantity: i, SalePrice: 10, ListPrice: 10})
+	}
+	items = append(items, trendyol.PriceInventoryItem{Barcode: "MISSING", Quantity: 1, SalePrice: 10, ListPrice: 10})
+
+	res, err := client.PriceInventory.UpdateChunked(context.Background(), items, &trendyol.ChunkOptions{
+		ChunkSize:   3,
+		Concurrency: 2,
+		Wait:        true,
+		WaitOptions: fastWait,
+	})
+	if err != nil {
+		t.Fatalf("UpdateChunked: %v", err)
+	}
+	if len(res.Batches) != 3 {
+		t.Fatalf("3 batch beklendi, gelen %d", len(res.Batches))
+	}
+	if n := len(srv.Calls(trendyol.EndpointUpdatePriceInventoryKey)); n != 3 {
+		t.Errorf("3 gönderim beklendi, gelen %d", n)
+	}
+	for _, it := range items {
+		got, ok := res.Items[it.Barcode]
+		if !ok || got.BatchRequestID == "" || got.Status == "" {
+			t.Errorf("%s için batch ID ve durum beklendi: %+v", it.Barcode, got)
+		}
+	}
+	if failed := res.Failed(); len(failed) != 1 || failed[0] != "MISSING" {
+		t.Errorf("yalnızca MISSING başarısız olmalı: %v", failed)
+	}
+}
+
+// TestProductCreateChunkedCapsChunkSize parça boyutunun uç nokta sınırıyla kısıtlandığını doğrular.
+func TestProductCreateChunkedCapsChunkSize(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	products := make([]trendyol.Product, trendyol.MaxProductBatchSize+1)
+	for i := range products {
+		products[i] = fixtureProduct(fmt.Sprintf("P-%d", i))
+	}
+	res, err := client.Products.CreateChunked(context.Background(), products, &trendyol.ChunkOptions{ChunkSize: 10000})
+	if err != nil {
+		t.Fatalf("CreateChunked: %v", err)
+	}
+	if len(res.Batches) != 2 || len(res.Batches[1].Barcodes) != 1 {
+		t.Fatalf("2 batch (500+1) beklendi: %d", len(res.Batches))
+	}
+	if len(srv.Products()) != len(products) {
+		t.Errorf("tüm ürünler oluşturulmalıydı, gelen %d", len(srv.Products()))
+	}
+}
@@ -0,0 +1,183 @@
+package trendyol_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestClaimsListWithOptions iadelerin sipariş numarası, tarih aralığı, iade
+// ID'si ve kalem statüsüyle süzüldüğünü ve sipariş, müşteri, kargo ve kalem
+// bilgilerinin modele taşındığını doğrular.
+func TestClaimsListWithOptions(t *testing.T) {
+	clock := &testClock{now: time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)}
+	srv := trendyoltest.NewServer(trendyoltest.WithClock(clock.Now))
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	first := srv.AddOrder(trendyol.Order{OrderNumber: "CL-1", CustomerFirstName: "Ayşe", CustomerLastName: "Yılmaz",
+		Lines: []trendyol.OrderLine{{Barcode: "CL-B1", Quantity: 1, ProductName: "Hoodie", MerchantSKU: "STK-1", Price: 149.9}}})
+	second := srv.AddOrder(trendyol.Order{OrderNumber: "CL-2", Lines: []trendyol.OrderLine{{Barcode: "CL-B2", Quantity: 2}}})
+	if err := client.Test.SetClaimWaitingInAction(ctx, first.ID); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(48 * time.Hour)
+	if err := client.Test.SetClaimWaitingInAction(ctx, second.ID); err != nil {
+		t.Fatal(err)
+	}
+	srv.AddClaim(trendyol.Claim{OrderNumber: "CL-3", Items: []trendyol.ClaimItem{{OrderLine: trendyol.ClaimOrderLine{Barcode: "CL-B3"}}}})
+
+	claims, _, err := client.Claims.ListWithOptions(ctx, trendyol.ListClaimsOptions{OrderNumber: "CL-1", Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claims) != 1 {
+		t.Fatalf("CL-1 için tek iade beklendi: %+v", claims)
+	}
+	c := claims[0]
+	if c.OrderShipmentPackageID != first.ID || c.CustomerFirstName != "Ayşe" || c.CargoTrackingNumber == 0 || c.CargoProviderName == "" {
+		t.Errorf("sipariş, müşteri ve kargo bilgileri eksik: %+v", c)
+	}
+	line := c.Items[0].OrderLine
+	if line.ID != first.Lines[0].ID || line.ProductName != "Hoodie" || line.Barcode != "CL-B1" || len(c.Items[0].ClaimItems) != 1 {
+		t.Errorf("sipariş satırı bilgileri eksik: %+v", c.Items[0])
+	}
+	it := c.Items[0].ClaimItems[0]
+	if it.Status.Name != trendyol.ClaimItemStatusWaitingInAction || it.OrderLineItemID != line.ID ||
+		it.CustomerReason == nil || it.CustomerReason.Code == "" || it.Resolved {
+		t.Errorf("kalem bilgileri eksik: %+v", it)
+	}
+
+	since := clock.Now().Add(-time.Hour)
+	claims, _, err = client.Claims.ListWithOptions(ctx, trendyol.ListClaimsOptions{StartDate: &since, Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claims) != 2 || claims[0].OrderNumber != "CL-2" {
+		t.Errorf("tarih aralığında CL-2 ve CL-3 beklendi: %+v", claims)
+	}
+
+	claims, _, err = client.Claims.ListWithOptions(ctx, trendyol.ListClaimsOptions{ClaimIDs: []int64{c.ID, claims[1].ID}, Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claims) != 2 {
+		t.Errorf("iki iade ID'si için iki iade beklendi: %+v", claims)
+	}
+
+	if err := client.Claims.ApproveItems(ctx, c.ID, []int64{it.ID}); err != nil {
+		t.Fatal(err)
+	}
+	var accepted []trendyol.Claim
+	err = client.Claims.ForEachWithOptions(ctx, trendyol.ListClaimsOptions{ClaimItemStatus: trendyol.ClaimItemStatusAccepted, Size: 1}, func(c trendyol.Claim) error {
+		accepted = append(accepted, c)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accepted) != 1 || !accepted[0].Items[0].ClaimItems[0].AcceptedBySeller || !accepted[0].Items[0].ClaimItems[0].Resolved {
+		t.Errorf("onaylanan kalem satıcı tarafından kabul edilmiş görünmeliydi: %+v", accepted)
+	}
+}
+
+// getClaimsBody is a getClaims response page in the shape returned by the API
+const getClaimsBody = `{
+  "totalElements": 1,
+  "totalPages": 1,
+  "page": 0,
+  "size": 50,
+  "content": [{
+    "id": 5000012345,
+    "orderNumber": "10654411111",
+    "orderDate": 1612518154000,
+    "customerFirstName": "Ayşe",
+    "customerLastName": "Yılmaz",
+    "claimDate": 1612529770000,
+    "cargoTrackingNumber": 7340000012345678,
+    "cargoTrackingLink": "https://kargotakip.trendyol.com/?token=abc",
+    "cargoSenderNumber": "210001234567",
+    "cargoProviderName": "Trendyol Express Marketplace",
+    "orderShipmentPackageId": 3000012345,
+    "orderOutboundPackageId": 3000012340,
+    "items": [{
+      "orderLine": {
+        "id": 4000012345,
+        "productName": "Kapüşonlu Sweatshirt",
+        "barcode": "8680000000017",
+        "merchantSku": "STK-17",
+        "productColor": "Siyah",
+        "productSize": "M",
+        "price": 149.9,
+        "vatBaseAmount": 124.92,
+        "vatRate": 20,
+        "salesCampaignId": 101,
+        "productCategory": "Sweatshirt"
+      },
+      "claimItems": [{
+        "id": 6000012345,
+        "orderLineItemId": 4000012345,
+        "customerClaimItemReason": {"id": 401, "name": "Bedeni küçük geldi", "externalReasonId": 15, "code": "SMALL_SIZE"},
+        "trendyolClaimItemReason": {"id": 401, "name": "Bedeni küçük geldi", "externalReasonId": 15, "code": "SMALL_SIZE"},
+        "claimItemStatus": {"name": "Accepted"},
+        "note": "",
+        "customerNote": "Bir beden büyüğünü alacağım",
+        "resolved": true,
+        "autoAccepted": false,
+        "acceptedBySeller": true
+      }, {
+        "id": 6000012346,
+        "orderLineItemId": 4000012346,
+        "customerClaimItemReason": {"id": 401, "name": "Bedeni küçük geldi", "externalReasonId": 15, "code": "SMALL_SIZE"},
+        "trendyolClaimItemReason": null,
+        "claimItemStatus": {"name": "WaitingInAction"},
+        "note": "",
+        "customerNote": "",
+        "resolved": false,
+        "autoAccepted": false,
+        "acceptedBySeller": false
+      }]
+    }],
+    "lastModifiedDate": 1612530000000
+  }]
+}`
+
+// TestClaimDecode API'nin döndüğü iç içe sipariş satırı ve iade kalemi
+// yapısının modele eksiksiz okunduğunu doğrular.
+func TestClaimDecode(t *testing.T) {
+	var page struct {
+		Content []trendyol.Claim `json:"content"`
+	}
+	if err := json.Unmarshal([]byte(getClaimsBody), &page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Content) != 1 || len(page.Content[0].Items) != 1 {
+		t.Fatalf("tek iade ve tek satır beklendi: %+v", page.Content)
+	}
+	c := page.Content[0]
+	if c.OrderShipmentPackageID != 3000012345 || c.CargoTrackingNumber != 7340000012345678 || c.CargoSenderNumber != "210001234567" {
+		t.Errorf("iade bilgileri eksik: %+v", c)
+	}
+
+	line := c.Items[0].OrderLine
+	if line.ID != 4000012345 || line.Barcode != "8680000000017" || line.ProductSize != "M" || line.Price != 149.9 || line.SalesCampaignID != 101 {
+		t.Errorf("sipariş satırı beklenmedik: %+v", line)
+	}
+	details := c.Items[0].ClaimItems
+	if len(details) != 2 {
+		t.Fatalf("iki iade kalemi beklendi: %+v", details)
+	}
+	d := details[0]
+	if d.ID != 6000012345 || d.Status.Name != trendyol.ClaimItemStatusAccepted || !d.Resolved || !d.AcceptedBySeller ||
+		d.CustomerReason == nil || d.CustomerReason.Code != "SMALL_SIZE" || d.TrendyolReason == nil || d.CustomerNote == "" {
+		t.Errorf("ilk kalem beklenmedik: %+v", d)
+	}
+	if d := details[1]; d.Status.Name != trendyol.ClaimItemStatusWaitingInAction || d.TrendyolReason != nil || d.Resolved {
+		t.Errorf("ikinci kalem beklenmedik: %+v", d)
+	}
+}
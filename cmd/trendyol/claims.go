@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+func claimsList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("claims list")
+	status := fs.String("status", "", "claim item status, e.g. WaitingInAction")
+	order := fs.String("order", "", "order number")
+	start := fs.String("start", "", "start date (2006-01-02 or RFC3339)")
+	end := fs.String("end", "", "end date (2006-01-02 or RFC3339)")
+	page := fs.Int("page", 0, "page number")
+	size := fs.Int("size", 50, "page size")
+	all := fs.Bool("all", false, "walk every page")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	opts := trendyol.ListClaimsOptions{ClaimItemStatus: *status, OrderNumber: *order, Page: *page, Size: *size}
+	var err error
+	if opts.StartDate, err = parseDate(*start); err != nil {
+		return err
+	}
+	if opts.EndDate, err = parseDate(*end); err != nil {
+		return err
+	}
+	for _, id := range fs.Args() {
+		claimID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid claim ID %q", id)
+		}
+		opts.ClaimIDs = append(opts.ClaimIDs, claimID)
+	}
+
+	var claims []trendyol.Claim
+	if *all {
+		err = a.client.Claims.ForEachWithOptions(ctx, opts, func(c trendyol.Claim) error {
+			claims = append(claims, c)
+			return nil
+		})
+	} else {
+		claims, _, err = a.client.Claims.ListWithOptions(ctx, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, c := range claims {
+		for _, it := range c.Items {
+			for _, d := range it.ClaimItems {
+				var reason string
+				if d.CustomerReason != nil {
+					reason = d.CustomerReason.Name
+				}
+				rows = append(rows, []string{
+					strconv.FormatInt(c.ID, 10),
+					c.OrderNumber,
+					time.UnixMilli(c.CreatedDate).Format(time.RFC3339),
+					strconv.FormatInt(d.ID, 10),
+					d.Status.Name,
+					it.OrderLine.Barcode,
+					reason,
+				})
+			}
+		}
+	}
+	return a.out.print(claims, []string{"CLAIM_ID", "ORDER_NUMBER", "CREATED", "ITEM_ID", "ITEM_STATUS", "BARCODE", "REASON"}, rows)
+}
+
+// parseIDs parses a claim ID followed by one or more item IDs
+func parseIDs(args []string) (int64, []int64, error) {
+	if len(args) < 2 {
+		return 0, nil, errors.New("expected a claim ID followed by at least one item ID")
+	}
+	ids := make([]int64, len(args))
+	for i, s := range args {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid ID %q", s)
+		}
+		ids[i] = id
+	}
+	return ids[0], ids[1:], nil
+}
+
+func claimsApprove(ctx context.Context, a *app, args []string) error {
+	claimID, itemIDs, err := parseIDs(args)
+	if err != nil {
+		return err
+	}
+	if err := a.client.Claims.ApproveItems(ctx, claimID, itemIDs); err != nil {
+		return err
+	}
+	return a.out.message(map[string]interface{}{"claimId": claimID, "approvedItemIds": itemIDs},
+		"claim %d: approved %d item(s)", claimID, len(itemIDs))
+}
+
+func claimsReject(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("claims reject")
+	reason := fs.Int("reason", 0, "claim issue reason ID, see the claim reasons endpoint")
+	desc := fs.String("desc", "", "rejection description")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *reason == 0 {
+		return errors.New("-reason is required")
+	}
+	claimID, itemIDs, err := parseIDs(fs.Args())
+	if err != nil {
+		return err
+	}
+	if err := a.client.Claims.RejectItems(ctx, claimID, *reason, itemIDs, *desc); err != nil {
+		return err
+	}
+	return a.out.message(map[string]interface{}{"claimId": claimID, "rejectedItemIds": itemIDs},
+		"claim %d: rejected %d item(s)", claimID, len(itemIDs))
+}
@@ -0,0 +1,209 @@
+// Command trendyol is a command-line tool for day-to-day seller operations on
+// the Trendyol Marketplace API, built on the trendyol package.
+//
+// Credentials are read from SELLER_ID, API_KEY and API_SECRET, either from the
+// environment or from a .env file (see env.example). TRENDYOL_BASE_URL
+// overrides the API base URL, e.g. to point the tool at a trendyoltest server.
+//
+// Usage:
+//
+//	trendyol [-o table|json|csv] [-sandbox] [-env .env] <group> <command> [flags] [args]
+//
+// Examples:
+//
+//	trendyol products list -size 100
+//	trendyol -o json products get ABC-001
+//	trendyol products create -file products.json -wait
+//	trendyol stock set -qty 5 -sale 149.90 -list 249.90 ABC-001
+//	trendyol orders pick 123456789
+//	trendyol label get -out label.zpl 7330000000000
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/vahaponur/trendyol-go"
+)
+
+// command runs a subcommand with its remaining arguments
+type command struct {
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+// commands groups subcommands by resource
+var commands = map[string]map[string]command{
+	"products": {
+		"list":   {"[-page N] [-size N] [-all] [-barcode B] [-approved true|false]", productsList},
+		"get":    {"<barcode>", productsGet},
+		"create": {"-file products.json|products.csv [-wait] [-chunk N]", productsCreate},
+		"update": {"-file products.json|products.csv [-wait] [-chunk N]", productsUpdate},
+		"export": {"[-file products.csv] [-excel] [-size N]", productsExport},
+		"delete": {"[-wait] <barcode>...", productsDelete},
+	},
+	"batch": {
+		"wait": {"[-timeout 5m] <batchRequestId>", batchWait},
+	},
+	"stock": {
+		"set": {"-qty N [-sale P] [-list P] [-wait] <barcode>", stockSet},
+	},
+	"orders": {
+		"list":    {"[-status S] [-start 2006-01-02] [-end 2006-01-02] [-page N] [-size N] [-all]", ordersList},
+		"pick":    {"<packageId>", ordersPick},
+		"invoice": {"[-number N] [-link URL] <packageId>", ordersInvoice},
+		"ship":    {"[-tracking N] [-boxes N -deci D] <packageId>", ordersShip},
+	},
+	"claims": {
+		"list":    {"[-status S] [-order N] [-start 2006-01-02] [-end 2006-01-02] [-page N] [-size N] [-all] [claimId...]", claimsList},
+		"approve": {"<claimId> <itemId>...", claimsApprove},
+		"reject":  {"-reason ID [-desc TEXT] <claimId> <itemId>...", claimsReject},
+	},
+	"webhooks": {
+		"list":       {"", webhooksList},
+		"create":     {"-url URL [-auth BASIC_AUTHENTICATION|API_KEY] [-user U -pass P | -apikey K] [-statuses A,B]", webhooksCreate},
+		"activate":   {"<webhookId>", webhooksActivate},
+		"deactivate": {"<webhookId>", webhooksDeactivate},
+	},
+	"label": {
+		"get": {"[-create] [-boxes N] [-out FILE] <cargoTrackingNumber>", labelGet},
+	},
+}
+
+// app carries the state shared by all subcommands
+type app struct {
+	client *trendyol.Client
+	out    *printer
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run parses the global flags, builds the client and dispatches the subcommand
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("trendyol", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("o", "table", "output format: table, json or csv")
+	sandbox := fs.Bool("sandbox", false, "use the stage (sandbox) API")
+	envFile := fs.String("env", ".env", "file to load credentials from")
+	timeout := fs.Duration("timeout", 2*time.Minute, "overall timeout of the command")
+	fs.Usage = func() { usage(stderr) }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	rest := fs.Args()
+	if len(rest) < 2 {
+		usage(stderr)
+		return 2
+	}
+	group, ok := commands[rest[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command group %q\n", rest[0])
+		usage(stderr)
+		return 2
+	}
+	cmd, ok := group[rest[1]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q %q\n", rest[0], rest[1])
+		usage(stderr)
+		return 2
+	}
+
+	out, err := newPrinter(stdout, *format)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	client, err := newClient(*envFile, *sandbox)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	a := &app{client: client, out: out, stdout: stdout, stderr: stderr}
+	if err := cmd.run(ctx, a, rest[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintf(stderr, "%s %s: %v\n", rest[0], rest[1], err)
+		return 1
+	}
+	return 0
+}
+
+// newClient reads credentials the same way the integration tests do
+func newClient(envFile string, sandbox bool) (*trendyol.Client, error) {
+	// A missing .env file is fine, the variables may come from the environment
+	_ = godotenv.Load(envFile)
+
+	sellerID := os.Getenv("SELLER_ID")
+	apiKey := os.Getenv("API_KEY")
+	apiSecret := os.Getenv("API_SECRET")
+	if sellerID == "" || apiKey == "" || apiSecret == "" {
+		return nil, errors.New("SELLER_ID, API_KEY and API_SECRET must be set in the environment or .env")
+	}
+
+	client := trendyol.NewClient(sellerID, apiKey, apiSecret, sandbox)
+	if base := os.Getenv("TRENDYOL_BASE_URL"); base != "" {
+		client.SetBaseURL(base)
+	}
+	return client, nil
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: trendyol [-o table|json|csv] [-sandbox] [-env .env] [-timeout 2m] <group> <command> [flags] [args]")
+	fmt.Fprintln(w)
+	groups := make([]string, 0, len(commands))
+	for g := range commands {
+		groups = append(groups, g)
+	}
+	sort.Strings(groups)
+	for _, g := range groups {
+		names := make([]string, 0, len(commands[g]))
+		for n := range commands[g] {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			fmt.Fprintf(w, "  %s %s %s\n", g, n, commands[g][n].usage)
+		}
+	}
+}
+
+// newFlags returns a flag set for a subcommand that reports errors to stderr
+func (a *app) newFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// splitList splits a comma separated flag value
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
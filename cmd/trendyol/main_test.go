@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// newTestServer sahte sunucuyu başlatır ve CLI'ın okuduğu ortam değişkenlerini ayarlar.
+func newTestServer(t *testing.T) *trendyoltest.Server {
+	t.Helper()
+	srv := trendyoltest.NewServer(trendyoltest.WithBatchPolls(0))
+	t.Cleanup(srv.Close)
+	t.Setenv("SELLER_ID", srv.SellerID())
+	t.Setenv("API_KEY", trendyoltest.DefaultAPIKey)
+	t.Setenv("API_SECRET", trendyoltest.DefaultAPISecret)
+	t.Setenv("TRENDYOL_BASE_URL", srv.URL)
+	return srv
+}
+
+func runCLI(t *testing.T, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-env", filepath.Join(t.TempDir(), ".env")}, args...)
+	code := run(context.Background(), args, &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+// TestProductsListFormats ürün listesinin tablo, JSON ve CSV çıktılarını doğrular.
+func TestProductsListFormats(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddProduct(trendyol.Product{Barcode: "CLI-1", Title: "Hoodie", Quantity: 3, SalePrice: 100, ListPrice: 120})
+
+	out, errOut, code := runCLI(t, "products", "list")
+	if code != 0 {
+		t.Fatalf("çıkış kodu %d: %s", code, errOut)
+	}
+	if !strings.Contains(out, "BARCODE") || !strings.Contains(out, "CLI-1") {
+		t.Errorf("tablo çıktısı beklenmedik:\n%s", out)
+	}
+
+	out, _, _ = runCLI(t, "-o", "json", "products", "list")
+	var products []trendyol.Product
+	if err := json.Unmarshal([]byte(out), &products); err != nil || len(products) != 1 {
+		t.Errorf("JSON çıktısı çözülemedi: %v\n%s", err, out)
+	}
+
+	out, _, _ = runCLI(t, "-o", "csv", "products", "list")
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil || len(records) != 2 || records[1][0] != "CLI-1" || records[1][4] != "100.00" {
+		t.Errorf("CSV çıktısı beklenmedik: %v %v", records, err)
+	}
+}
+
+// TestProductsCreateAndStock dosyadan ürün oluşturmayı ve mevcut fiyatları koruyarak
+// stok güncellemeyi doğrular.
+func TestProductsCreateAndStock(t *testing.T) {
+	srv := newTestServer(t)
+
+	p := trendyol.Product{
+		Barcode: "CLI-2", Title: "Tişört", ProductMainID: "M-2", BrandID: trendyoltest.FixtureBrandID,
+		CategoryID: trendyoltest.FixtureCategoryID, Quantity: 1, StockCode: "S-2", CurrencyType: "TRY",
+		ListPrice: 200, SalePrice: 150, VATRate: 20, Images: []trendyol.ProductImage{{URL: "https://example.com/a.jpg"}},
+		Attributes: []trendyol.ProductAttribute{{AttributeID: trendyoltest.FixtureOriginAttribute, AttributeValueID: trendyoltest.FixtureOriginTR}},
+	}
+	data, _ := json.Marshal(map[string]interface{}{"items": []trendyol.Product{p}})
+	file := filepath.Join(t.TempDir(), "products.json")
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, errOut, code := runCLI(t, "products", "create", "-file", file, "-wait")
+	if code != 0 {
+		t.Fatalf("çıkış kodu %d: %s", code, errOut)
+	}
+	if !strings.Contains(out, trendyol.BatchItemStatusSuccess) {
+		t.Errorf("başarılı kalem beklendi:\n%s", out)
+	}
+
+	if _, errOut, code = runCLI(t, "stock", "set", "-qty", "42", "-wait", "CLI-2"); code != 0 {
+		t.Fatalf("stok güncellenemedi: %s", errOut)
+	}
+	got, _ := srv.Product("CLI-2")
+	if got.Quantity != 42 || got.SalePrice != 150 || got.ListPrice != 200 {
+		t.Errorf("stok/fiyat beklenmedik: %+v", got)
+	}
+}
+
+// TestProductsExportAndImportCSV kataloğun Excel uyumlu CSV olarak dışa
+// aktarıldığını ve düzenlenen dosyanın güncelleme olarak gönderildiğini doğrular.
+func TestProductsExportAndImportCSV(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddProduct(trendyol.Product{
+		Barcode: "CLI-CSV", Title: "Hoodie", ProductMainID: "M-CSV", BrandID: trendyoltest.FixtureBrandID,
+		CategoryID: trendyoltest.FixtureCategoryID, Quantity: 1, StockCode: "S-CSV", CurrencyType: "TRY",
+		ListPrice: 200, SalePrice: 150, VATRate: 20, Images: []trendyol.ProductImage{{URL: "https://example.com/a.jpg"}},
+		Attributes: []trendyol.ProductAttribute{{AttributeID: trendyoltest.FixtureOriginAttribute, AttributeValueID: trendyoltest.FixtureOriginTR}},
+	})
+
+	file := filepath.Join(t.TempDir(), "products.csv")
+	if _, errOut, code := runCLI(t, "products", "export", "-excel", "-file", file); code != 0 {
+		t.Fatalf("dışa aktarılamadı: %s", errOut)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Menşei=TR") {
+		t.Fatalf("özellik adı yazılmalıydı:\n%s", data)
+	}
+
+	edited := strings.Replace(string(data), ";Hoodie;", ";Hoodie V2;", 1)
+	if err := os.WriteFile(file, []byte(edited), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, errOut, code := runCLI(t, "products", "update", "-file", file, "-wait"); code != 0 {
+		t.Fatalf("CSV ile güncellenemedi: %s", errOut)
+	}
+	if got, _ := srv.Product("CLI-CSV"); got.Title != "Hoodie V2" {
+		t.Errorf("başlık güncellenmeliydi: %q", got.Title)
+	}
+}
+
+// TestOrdersPickInvoiceAndLabel paketi toplama, faturalama ve etiket alma adımlarını doğrular.
+func TestOrdersPickInvoiceAndLabel(t *testing.T) {
+	srv := newTestServer(t)
+	o := srv.AddOrder(trendyol.Order{Lines: []trendyol.OrderLine{{Quantity: 2, Barcode: "CLI-3"}}})
+	id := strconv.FormatInt(o.ID, 10)
+
+	if _, errOut, code := runCLI(t, "orders", "pick", id); code != 0 {
+		t.Fatalf("pick: %s", errOut)
+	}
+	if _, errOut, code := runCLI(t, "orders", "invoice", "-number", "INV-1", "-link", "https://example.com/inv.pdf", id); code != 0 {
+		t.Fatalf("invoice: %s", errOut)
+	}
+	got, _ := srv.Order(o.ID)
+	if got.Status != trendyol.StatusInvoiced {
+		t.Errorf("Invoiced beklendi, gelen %s", got.Status)
+	}
+	if link, _ := srv.InvoiceLink(o.ID); link != "https://example.com/inv.pdf" {
+		t.Errorf("fatura linki gönderilmedi: %q", link)
+	}
+
+	tracking := strconv.FormatInt(o.CargoTrackingNumber, 10)
+	out, errOut, code := runCLI(t, "label", "get", "-create", "-boxes", "2", tracking)
+	if code != 0 {
+		t.Fatalf("label: %s", errOut)
+	}
+	if strings.Count(out, "^XA") != 2 {
+		t.Errorf("iki kolilik ZPL beklendi:\n%s", out)
+	}
+}
+
+// TestWebhooksAndClaims webhook oluşturma/listeleme ve iade onayını doğrular.
+func TestWebhooksAndClaims(t *testing.T) {
+	srv := newTestServer(t)
+
+	out, errOut, code := runCLI(t, "-o", "json", "webhooks", "create", "-url", "https://example.com/hook",
+		"-user", "u", "-pass", "secret-pass", "-statuses", "CREATED,PICKING")
+	if code != 0 {
+		t.Fatalf("create: %s", errOut)
+	}
+	var created map[string]string
+	if err := json.Unmarshal([]byte(out), &created); err != nil || created["id"] == "" {
+		t.Fatalf("webhook ID bekleniyordu: %s", out)
+	}
+	if _, errOut, code = runCLI(t, "webhooks", "activate", created["id"]); code != 0 {
+		t.Fatalf("activate: %s", errOut)
+	}
+	out, _, _ = runCLI(t, "-o", "json", "webhooks", "list")
+	if strings.Contains(out, "secret-pass") || !strings.Contains(out, "https://example.com/hook") {
+		t.Errorf("liste beklenmedik veya parola içeriyor:\n%s", out)
+	}
+
+	o := srv.AddOrder(trendyol.Order{Lines: []trendyol.OrderLine{{Quantity: 1, Barcode: "CLI-4"}}})
+	if err := srv.Client().Test.SetClaimWaitingInAction(context.Background(), o.ID); err != nil {
+		t.Fatal(err)
+	}
+	out, _, _ = runCLI(t, "-o", "json", "claims", "list", "-status", "WaitingInAction")
+	var claims []trendyol.Claim
+	if err := json.Unmarshal([]byte(out), &claims); err != nil || len(claims) != 1 {
+		t.Fatalf("tek iade beklendi: %v\n%s", err, out)
+	}
+	itemID := claims[0].Items[0].ClaimItems[0].ID
+	args := []string{"claims", "approve", strconv.FormatInt(claims[0].ID, 10), strconv.FormatInt(itemID, 10)}
+	if _, errOut, code = runCLI(t, args...); code != 0 {
+		t.Fatalf("approve: %s", errOut)
+	}
+	if st, _ := srv.ClaimItemStatus(itemID); st != "Accepted" {
+		t.Errorf("Accepted beklendi, gelen %s", st)
+	}
+}
+
+// TestUsageErrors eksik kimlik bilgisi ve bilinmeyen komut durumlarını doğrular.
+func TestUsageErrors(t *testing.T) {
+	newTestServer(t)
+	if _, errOut, code := runCLI(t, "products", "explode"); code != 2 || !strings.Contains(errOut, "unknown command") {
+		t.Errorf("bilinmeyen komut için 2 beklendi: %d %s", code, errOut)
+	}
+	if _, _, code := runCLI(t, "-o", "xml", "products", "list"); code != 2 {
+		t.Errorf("geçersiz çıktı biçimi için 2 beklendi: %d", code)
+	}
+
+	t.Setenv("API_SECRET", "")
+	if _, errOut, code := runCLI(t, "products", "list"); code != 1 || !strings.Contains(errOut, "API_SECRET") {
+		t.Errorf("eksik kimlik bilgisi hatası beklendi: %d %s", code, errOut)
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+var orderHeader = []string{"PACKAGE_ID", "ORDER_NUMBER", "STATUS", "LINES", "TOTAL", "CARGO", "TRACKING", "LAST_MODIFIED"}
+
+func orderRow(o trendyol.Order) []string {
+	tracking := ""
+	if o.CargoTrackingNumber != 0 {
+		tracking = strconv.FormatInt(o.CargoTrackingNumber, 10)
+	}
+	return []string{
+		strconv.FormatInt(o.ID, 10),
+		o.OrderNumber,
+		string(o.Status),
+		strconv.Itoa(len(o.Lines)),
+		formatPrice(o.TotalPrice),
+		o.CargoProviderName,
+		tracking,
+		time.UnixMilli(o.LastModifiedDate).Format(time.RFC3339),
+	}
+}
+
+func ordersList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("orders list")
+	status := fs.String("status", "", "package status, e.g. Created")
+	start := fs.String("start", "", "start date (2006-01-02 or RFC3339)")
+	end := fs.String("end", "", "end date (2006-01-02 or RFC3339)")
+	page := fs.Int("page", 0, "page number")
+	size := fs.Int("size", 50, "page size")
+	all := fs.Bool("all", false, "walk every page")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	opts := trendyol.ListOrdersOptions{Status: trendyol.PackageStatus(*status), Page: *page, Size: *size}
+	var err error
+	if opts.StartDate, err = parseDate(*start); err != nil {
+		return err
+	}
+	if opts.EndDate, err = parseDate(*end); err != nil {
+		return err
+	}
+
+	var orders []trendyol.Order
+	if *all {
+		err = a.client.Orders.ForEach(ctx, opts, func(o trendyol.Order) error {
+			orders = append(orders, o)
+			return nil
+		})
+	} else {
+		orders, _, err = a.client.Orders.List(ctx, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(orders))
+	for i, o := range orders {
+		rows[i] = orderRow(o)
+	}
+	return a.out.print(orders, orderHeader, rows)
+}
+
+func parseDate(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid date %q, use 2006-01-02 or RFC3339", s)
+}
+
+// findOrder loads a shipment package by ID
+func findOrder(ctx context.Context, a *app, arg string) (*trendyol.Order, error) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid package ID %q", arg)
+	}
+	orders, _, err := a.client.Orders.List(ctx, trendyol.ListOrdersOptions{ShipmentPackageIDs: []int64{id}, Size: 1})
+	if err != nil {
+		return nil, err
+	}
+	for i := range orders {
+		if orders[i].ID == id {
+			return &orders[i], nil
+		}
+	}
+	return nil, fmt.Errorf("shipment package %d not found", id)
+}
+
+func ordersPick(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return errors.New("expected exactly one package ID")
+	}
+	o, err := findOrder(ctx, a, args[0])
+	if err != nil {
+		return err
+	}
+	if err := a.client.Orders.MarkPicking(ctx, *o); err != nil {
+		return err
+	}
+	return a.out.message(map[string]interface{}{"packageId": o.ID, "status": trendyol.StatusPicking},
+		"package %d: %s", o.ID, trendyol.StatusPicking)
+}
+
+func ordersInvoice(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("orders invoice")
+	number := fs.String("number", "", "invoice number, moves the package to Invoiced")
+	link := fs.String("link", "", "invoice PDF link to send")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("expected exactly one package ID")
+	}
+	if *number == "" && *link == "" {
+		return errors.New("at least one of -number or -link is required")
+	}
+	o, err := findOrder(ctx, a, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if *number != "" {
+		if err := a.client.Orders.MarkInvoiced(ctx, *o, *number); err != nil {
+			return err
+		}
+	}
+	if *link != "" {
+		if err := a.client.Orders.SendInvoiceLink(ctx, o.ID, *link); err != nil {
+			return err
+		}
+	}
+	return a.out.message(map[string]interface{}{"packageId": o.ID, "invoiceNumber": *number, "invoiceLink": *link},
+		"package %d: invoiced", o.ID)
+}
+
+func ordersShip(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("orders ship")
+	tracking := fs.String("tracking", "", "cargo tracking number for seller-managed shipping")
+	boxes := fs.Int("boxes", 0, "box quantity")
+	deci := fs.Float64("deci", 0, "total deci of the boxes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("expected exactly one package ID")
+	}
+	if *tracking == "" && *boxes == 0 {
+		return errors.New("at least one of -tracking or -boxes is required")
+	}
+	id, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid package ID %q", fs.Arg(0))
+	}
+
+	if *boxes > 0 {
+		if err := a.client.Orders.UpdateBoxInfo(ctx, id, *boxes, *deci); err != nil {
+			return err
+		}
+	}
+	if *tracking != "" {
+		if err := a.client.Orders.UpdateTrackingNumber(ctx, id, *tracking); err != nil {
+			return err
+		}
+	}
+	return a.out.message(map[string]interface{}{"packageId": id, "trackingNumber": *tracking, "boxQuantity": *boxes},
+		"package %d: shipping info updated", id)
+}
+
+func labelGet(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("label get")
+	create := fs.Bool("create", false, "create the common label before fetching it")
+	boxes := fs.Int("boxes", 1, "box quantity used with -create")
+	out := fs.String("out", "", "write the label to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("expected exactly one cargo tracking number")
+	}
+	tracking := fs.Arg(0)
+
+	if *create {
+		err := a.client.CommonLabel.CreateLabel(ctx, tracking, trendyol.CommonLabelRequest{Format: "ZPL", BoxQuantity: *boxes})
+		if err != nil {
+			return err
+		}
+	}
+	raw, err := a.client.CommonLabel.GetLabel(ctx, tracking)
+	if err != nil {
+		return err
+	}
+	label := trendyol.ExtractLabel(raw)
+
+	if *out != "" {
+		if err := os.WriteFile(*out, label, 0o644); err != nil {
+			return err
+		}
+		return a.out.message(map[string]interface{}{"cargoTrackingNumber": tracking, "file": *out, "bytes": len(label)},
+			"label written to %s (%d bytes)", *out, len(label))
+	}
+	_, err = a.stdout.Write(label)
+	return err
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// printer renders results as an aligned table, JSON or CSV
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case "table", "json", "csv":
+		return &printer{w: w, format: format}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q, use table, json or csv", format)
+}
+
+// print writes v as JSON, or header and rows as a table or CSV
+func (p *printer) print(v interface{}, header []string, rows [][]string) error {
+	switch p.format {
+	case "json":
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "csv":
+		cw := csv.NewWriter(p.w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	default:
+		tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, r := range rows {
+			fmt.Fprintln(tw, strings.Join(r, "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+// message prints a single status line, or a JSON object in JSON mode
+func (p *printer) message(v interface{}, format string, args ...interface{}) error {
+	if p.format == "json" {
+		return p.print(v, nil, nil)
+	}
+	_, err := fmt.Fprintf(p.w, format+"\n", args...)
+	return err
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+var productHeader = []string{"BARCODE", "TITLE", "STOCK_CODE", "QUANTITY", "SALE_PRICE", "LIST_PRICE", "APPROVED", "ARCHIVED"}
+
+func productRow(p trendyol.Product) []string {
+	return []string{
+		p.Barcode,
+		p.Title,
+		p.StockCode,
+		strconv.Itoa(p.Quantity),
+		formatPrice(p.SalePrice),
+		formatPrice(p.ListPrice),
+		strconv.FormatBool(p.Approved),
+		strconv.FormatBool(p.Archived),
+	}
+}
+
+func formatPrice(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func productsList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("products list")
+	page := fs.Int("page", 0, "page number")
+	size := fs.Int("size", 50, "page size")
+	all := fs.Bool("all", false, "walk every page")
+	barcode := fs.String("barcode", "", "filter by barcode")
+	approved := fs.String("approved", "", "filter by approval: true or false")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	opts := &trendyol.ProductListOptions{Barcode: *barcode}
+	if *approved != "" {
+		v, err := strconv.ParseBool(*approved)
+		if err != nil {
+			return fmt.Errorf("invalid -approved value: %w", err)
+		}
+		opts.Approved = &v
+	}
+
+	var products []trendyol.Product
+	if *all {
+		err := a.client.Products.ForEach(ctx, *size, opts, func(p trendyol.Product) error {
+			products = append(products, p)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		var err error
+		products, _, err = a.client.Products.ListWithOptions(ctx, *page, *size, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	rows := make([][]string, len(products))
+	for i, p := range products {
+		rows[i] = productRow(p)
+	}
+	return a.out.print(products, productHeader, rows)
+}
+
+func productsGet(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return errors.New("expected exactly one barcode")
+	}
+	p, err := a.client.Products.GetByBarcode(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return a.out.print(p, productHeader, [][]string{productRow(*p)})
+}
+
+func productsCreate(ctx context.Context, a *app, args []string) error {
+	return submitProducts(ctx, a, "products create", args, a.client.Products.CreateChunked)
+}
+
+func productsUpdate(ctx context.Context, a *app, args []string) error {
+	return submitProducts(ctx, a, "products update", args, a.client.Products.UpdateChunked)
+}
+
+func submitProducts(ctx context.Context, a *app, name string, args []string,
+	submit func(context.Context, []trendyol.Product, *trendyol.ChunkOptions) (*trendyol.ChunkedResult, error)) error {
+	fs := a.newFlags(name)
+	file := fs.String("file", "", "JSON file with a product array or {\"items\": [...]}, or a .csv file")
+	wait := fs.Bool("wait", false, "wait for the batches to complete")
+	chunk := fs.Int("chunk", trendyol.MaxProductBatchSize, "products per batch request")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-file is required")
+	}
+
+	products, err := readProducts(ctx, a, *file)
+	if err != nil {
+		return err
+	}
+	res, err := submit(ctx, products, &trendyol.ChunkOptions{ChunkSize: *chunk, Wait: *wait})
+	if res != nil {
+		if perr := printChunked(a, res); perr != nil {
+			return perr
+		}
+	}
+	return err
+}
+
+// readProducts accepts a bare array, the API request shape or a CSV file
+// with the trendyol.ProductCSVColumns schema
+func readProducts(ctx context.Context, a *app, path string) ([]trendyol.Product, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var products []trendyol.Product
+	switch {
+	case strings.EqualFold(filepath.Ext(path), ".csv"):
+		products, err = readProductsCSV(ctx, a, path, data)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(strings.TrimSpace(string(data)), "["):
+		err = json.Unmarshal(data, &products)
+	default:
+		var req trendyol.CreateProductsRequest
+		err = json.Unmarshal(data, &req)
+		products = req.Items
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(products) == 0 {
+		return nil, fmt.Errorf("no products in %s", path)
+	}
+	return products, nil
+}
+
+// readProductsCSV decodes a spreadsheet export, detecting a ';' delimiter
+// from the header line. Any row error aborts the submission.
+func readProductsCSV(ctx context.Context, a *app, path string, data []byte) ([]trendyol.Product, error) {
+	opts := &trendyol.CSVOptions{Categories: a.client.Categories}
+	header, _, _ := strings.Cut(string(data), "\n")
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		opts.Comma = ';'
+	}
+	products, rowErrs, err := trendyol.ReadProductsCSV(ctx, bytes.NewReader(data), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, e := range rowErrs {
+		fmt.Fprintln(a.stderr, e)
+	}
+	if len(rowErrs) > 0 {
+		return nil, fmt.Errorf("%d row(s) of %s could not be read", len(rowErrs), path)
+	}
+	return products, nil
+}
+
+func productsExport(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("products export")
+	file := fs.String("file", "", "write to this CSV file instead of stdout")
+	excel := fs.Bool("excel", false, "use ';' and a UTF-8 BOM for Excel with a Turkish locale")
+	size := fs.Int("size", 100, "page size")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var products []trendyol.Product
+	err := a.client.Products.ForEach(ctx, *size, nil, func(p trendyol.Product) error {
+		products = append(products, p)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	opts := &trendyol.CSVOptions{Categories: a.client.Categories}
+	if *excel {
+		opts.Comma, opts.BOM = ';', true
+	}
+	if *file == "" {
+		return trendyol.WriteProductsCSV(ctx, a.stdout, products, opts)
+	}
+	f, err := os.Create(*file)
+	if err != nil {
+		return err
+	}
+	if err := trendyol.WriteProductsCSV(ctx, f, products, opts); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return a.out.message(map[string]interface{}{"file": *file, "products": len(products)},
+		"%d products written to %s", len(products), *file)
+}
+
+func printChunked(a *app, res *trendyol.ChunkedResult) error {
+	var rows [][]string
+	for _, b := range res.Batches {
+		for _, bc := range b.Barcodes {
+			it := res.Items[bc]
+			errText := ""
+			if it.Err != nil {
+				errText = it.Err.Error()
+			}
+			rows = append(rows, []string{bc, it.BatchRequestID, it.Status, strings.Join(it.FailureReasons, "; "), errText})
+		}
+	}
+	return a.out.print(res.Items, []string{"BARCODE", "BATCH_REQUEST_ID", "STATUS", "FAILURE_REASONS", "ERROR"}, rows)
+}
+
+func productsDelete(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("products delete")
+	wait := fs.Bool("wait", false, "wait for the batch to complete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("expected at least one barcode")
+	}
+	resp, err := a.client.Products.Delete(ctx, fs.Args())
+	if err != nil {
+		return err
+	}
+	if *wait {
+		return waitAndPrint(ctx, a, resp.BatchRequestID, nil)
+	}
+	return a.out.message(resp, "batchRequestId: %s", resp.BatchRequestID)
+}
+
+func batchWait(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("batch wait")
+	timeout := fs.Duration("timeout", 5*time.Minute, "give up after this duration")
+	interval := fs.Duration("interval", 2*time.Second, "initial polling interval")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("expected exactly one batch request ID")
+	}
+	return waitAndPrint(ctx, a, fs.Arg(0), &trendyol.WaitOptions{Interval: *interval, Timeout: *timeout})
+}
+
+func waitAndPrint(ctx context.Context, a *app, batchRequestID string, opts *trendyol.WaitOptions) error {
+	res, err := a.client.Products.WaitForBatch(ctx, batchRequestID, opts)
+	if res == nil {
+		return err
+	}
+	rows := make([][]string, len(res.Items))
+	for i, it := range res.Items {
+		rows[i] = []string{it.Barcode, it.Status, strings.Join(it.FailureReasons, "; ")}
+	}
+	if err := a.out.print(res.Status, []string{"BARCODE", "STATUS", "FAILURE_REASONS"}, rows); err != nil {
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if !res.Succeeded() {
+		return fmt.Errorf("%d item(s) failed", len(res.FailedItems()))
+	}
+	return nil
+}
+
+func stockSet(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("stock set")
+	qty := fs.Int("qty", -1, "stock quantity")
+	sale := fs.Float64("sale", 0, "sale price")
+	list := fs.Float64("list", 0, "list price")
+	wait := fs.Bool("wait", false, "wait for the batch to complete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("expected exactly one barcode")
+	}
+	if *qty < 0 {
+		return errors.New("-qty is required")
+	}
+
+	item := trendyol.PriceInventoryItem{Barcode: fs.Arg(0), Quantity: *qty, SalePrice: *sale, ListPrice: *list}
+	// Prices are always sent, keep the current ones unless given
+	if item.SalePrice == 0 || item.ListPrice == 0 {
+		p, err := a.client.Products.GetByBarcode(ctx, item.Barcode)
+		if err != nil {
+			return err
+		}
+		if item.SalePrice == 0 {
+			item.SalePrice = p.SalePrice
+		}
+		if item.ListPrice == 0 {
+			item.ListPrice = p.ListPrice
+		}
+	}
+	resp, err := a.client.PriceInventory.Update(ctx, []trendyol.PriceInventoryItem{item})
+	if err != nil {
+		return err
+	}
+	if *wait {
+		return waitAndPrint(ctx, a, resp.BatchRequestID, nil)
+	}
+	return a.out.message(resp, "batchRequestId: %s", resp.BatchRequestID)
+}
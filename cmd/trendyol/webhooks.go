@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+func webhooksList(ctx context.Context, a *app, args []string) error {
+	if len(args) != 0 {
+		return errors.New("unexpected arguments")
+	}
+	hooks, err := a.client.Webhooks.List(ctx)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, len(hooks))
+	for i, h := range hooks {
+		rows[i] = []string{h.ID, h.URL, h.AuthenticationType, strings.Join(h.SubscribedStatuses, ","), strconv.FormatBool(h.Active)}
+	}
+	// Never print stored credentials
+	for i := range hooks {
+		hooks[i].Password = ""
+		hooks[i].APIKey = ""
+	}
+	return a.out.print(hooks, []string{"ID", "URL", "AUTH", "STATUSES", "ACTIVE"}, rows)
+}
+
+func webhooksCreate(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("webhooks create")
+	url := fs.String("url", "", "callback URL")
+	auth := fs.String("auth", "BASIC_AUTHENTICATION", "authentication type: BASIC_AUTHENTICATION or API_KEY")
+	user := fs.String("user", "", "basic auth username")
+	pass := fs.String("pass", "", "basic auth password")
+	apiKey := fs.String("apikey", "", "API key for API_KEY authentication")
+	statuses := fs.String("statuses", "", "comma separated package statuses, empty for all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *url == "" {
+		return errors.New("-url is required")
+	}
+
+	id, err := a.client.Webhooks.Create(ctx, trendyol.CreateWebhookRequest{
+		URL:                *url,
+		Username:           *user,
+		Password:           *pass,
+		AuthenticationType: *auth,
+		APIKey:             *apiKey,
+		SubscribedStatuses: splitList(*statuses),
+	})
+	if err != nil {
+		return err
+	}
+	return a.out.message(map[string]string{"id": id}, "webhook created: %s", id)
+}
+
+func webhooksActivate(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return errors.New("expected exactly one webhook ID")
+	}
+	if err := a.client.Webhooks.Activate(ctx, args[0]); err != nil {
+		return err
+	}
+	return a.out.message(map[string]interface{}{"id": args[0], "active": true}, "webhook %s activated", args[0])
+}
+
+func webhooksDeactivate(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return errors.New("expected exactly one webhook ID")
+	}
+	if err := a.client.Webhooks.Deactivate(ctx, args[0]); err != nil {
+		return err
+	}
+	return a.out.message(map[string]interface{}{"id": args[0], "active": false}, "webhook %s deactivated", args[0])
+}
@@ -11,6 +11,7 @@ const (
 	EndpointCreateProductsKey        = "CreateProducts"
 	EndpointUpdateProductsKey        = "UpdateProducts"
 	EndpointDeleteProductsKey        = "DeleteProducts"
+	EndpointUpdateArchiveStateKey    = "UpdateArchiveState"
 	EndpointGetBatchRequestResultKey = "GetBatchRequestResult"
 )
 
@@ -69,6 +70,7 @@ const (
 const (
 	EndpointGetSettlementsKey         = "GetSettlements"
 	EndpointGetCargoInvoiceDetailsKey = "GetCargoInvoiceDetails"
+	EndpointGetOtherFinancialsKey     = "GetOtherFinancials"
 )
 
 // API Endpoints - Member Module
@@ -107,6 +109,7 @@ var defaultEndpoints = map[string]string{
 	EndpointCreateProductsKey:        "/integration/product/sellers/%s/products",
 	EndpointUpdateProductsKey:        "/integration/product/sellers/%s/products",
 	EndpointDeleteProductsKey:        "/integration/product/sellers/%s/products",
+	EndpointUpdateArchiveStateKey:    "/integration/product/sellers/%s/products/archive-state",
 	EndpointGetBatchRequestResultKey: "/integration/product/sellers/%s/products/batch-requests/%s",
 	EndpointGetBrandsKey:             "/integration/product/brands",
 	EndpointGetCategoriesKey:         "/integration/product/product-categories",
@@ -154,6 +157,7 @@ var defaultEndpoints = map[string]string{
 	// Finance Module
 	EndpointGetSettlementsKey:         "/integration/finance/sellers/%s/settlements",
 	EndpointGetCargoInvoiceDetailsKey: "/integration/finance/sellers/%s/cargo-invoice-details/%s",
+	EndpointGetOtherFinancialsKey:     "/integration/finance/sellers/%s/otherfinancials",
 
 	// Member Module
 	EndpointGetCountriesKey:      "/integration/member/countries",
@@ -176,3 +180,13 @@ var defaultEndpoints = map[string]string{
 	EndpointActivateWebhookKey:   "/integration/webhook/sellers/%s/webhooks/%s/activate",
 	EndpointDeactivateWebhookKey: "/integration/webhook/sellers/%s/webhooks/%s/deactivate",
 }
+
+// DefaultEndpoints returns a copy of the built-in endpoint templates keyed by
+// endpoint key. Modifying the returned map has no effect on the package.
+func DefaultEndpoints() map[string]string {
+	m := make(map[string]string, len(defaultEndpoints))
+	for k, v := range defaultEndpoints {
+		m[k] = v
+	}
+	return m
+}
@@ -0,0 +1,83 @@
+package trendyol
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors matched by *Error through errors.Is
+var (
+	ErrNotFound     = errors.New("trendyol: not found")
+	ErrUnauthorized = errors.New("trendyol: unauthorized")
+	ErrRateLimited  = errors.New("trendyol: rate limited")
+	ErrValidation   = errors.New("trendyol: validation failed")
+	ErrConflict     = errors.New("trendyol: conflict")
+	ErrServer       = errors.New("trendyol: server error")
+)
+
+// errCodeSentinels maps the error codes in the errors array to sentinels
+var errCodeSentinels = map[string]error{
+	ErrCodeValidation:     ErrValidation,
+	ErrCodeAuthentication: ErrUnauthorized,
+	ErrCodeRateLimit:      ErrRateLimited,
+	ErrCodeNotFound:       ErrNotFound,
+	ErrCodeInternal:       ErrServer,
+}
+
+// requestIDHeaders are the response headers checked for a request ID, in order
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-B3-Traceid"}
+
+// statusSentinel returns the sentinel for an HTTP status code
+func statusSentinel(status int) error {
+	switch {
+	case status == http.StatusNotFound:
+		return ErrNotFound
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrUnauthorized
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status == http.StatusConflict:
+		return ErrConflict
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case status >= 500:
+		return ErrServer
+	}
+	return nil
+}
+
+// Is reports whether target is the sentinel for the status code or for one
+// of the error codes of e, so that errors.Is(err, ErrNotFound) works on
+// wrapped API errors
+func (e *Error) Is(target error) bool {
+	if target == nil {
+		return false
+	}
+	if statusSentinel(e.StatusCode) == target {
+		return true
+	}
+	for _, item := range e.Errors {
+		if errCodeSentinels[item.Code] == target {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryError is returned when a request still failed after being retried
+type RetryError struct {
+	Attempts []error // error of every attempt, oldest first
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempts: %v", len(e.Attempts), e.Unwrap())
+}
+
+// Unwrap returns the error of the last attempt
+func (e *RetryError) Unwrap() error {
+	if len(e.Attempts) == 0 {
+		return nil
+	}
+	return e.Attempts[len(e.Attempts)-1]
+}
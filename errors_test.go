@@ -0,0 +1,88 @@
+package trendyol_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestErrorSentinels HTTP durum kodlarının ve hata kodlarının errors.Is ile
+// eşleştiğini doğrular.
+func TestErrorSentinels(t *testing.T) {
+	cases := []struct {
+		err  *trendyol.Error
+		want []error
+		not  []error
+	}{
+		{&trendyol.Error{StatusCode: http.StatusNotFound}, []error{trendyol.ErrNotFound}, []error{trendyol.ErrServer}},
+		{&trendyol.Error{StatusCode: http.StatusForbidden}, []error{trendyol.ErrUnauthorized}, nil},
+		{&trendyol.Error{StatusCode: http.StatusTooManyRequests}, []error{trendyol.ErrRateLimited}, nil},
+		{&trendyol.Error{StatusCode: http.StatusConflict, Errors: []trendyol.ErrorItem{{Code: trendyol.ErrCodeValidation}}},
+			[]error{trendyol.ErrConflict, trendyol.ErrValidation}, []error{trendyol.ErrNotFound}},
+		{&trendyol.Error{StatusCode: http.StatusBadGateway}, []error{trendyol.ErrServer}, []error{trendyol.ErrValidation}},
+		{&trendyol.Error{StatusCode: http.StatusOK, Errors: []trendyol.ErrorItem{{Code: trendyol.ErrCodeNotFound}}}, []error{trendyol.ErrNotFound}, nil},
+	}
+	for _, c := range cases {
+		for _, target := range c.want {
+			if !errors.Is(c.err, target) {
+				t.Errorf("%d %v, %v ile eşleşmeliydi", c.err.StatusCode, c.err.Errors, target)
+			}
+		}
+		for _, target := range c.not {
+			if errors.Is(c.err, target) {
+				t.Errorf("%d %v, %v ile eşleşmemeliydi", c.err.StatusCode, c.err.Errors, target)
+			}
+		}
+	}
+}
+
+// TestErrorContext hatanın uç nokta, metot, istek kimliği ve ham gövdeyi
+// taşıdığını doğrular.
+func TestErrorContext(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	srv.FailNext(trendyol.EndpointGetProductsKey, 1, http.StatusNotFound, trendyol.ErrCodeNotFound, "gone")
+	_, _, err := client.Products.List(context.Background(), 0, 10)
+	if !errors.Is(err, trendyol.ErrNotFound) {
+		t.Fatalf("ErrNotFound bekleniyordu: %v", err)
+	}
+	var apiErr *trendyol.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("*trendyol.Error bekleniyordu: %T", err)
+	}
+	if apiErr.Endpoint != trendyol.EndpointGetProductsKey || apiErr.Method != http.MethodGet {
+		t.Errorf("beklenmeyen istek bilgisi: %s %s", apiErr.Method, apiErr.Endpoint)
+	}
+	if apiErr.RequestID == "" || !strings.Contains(string(apiErr.Body), "gone") {
+		t.Errorf("istek kimliği ve gövde dolu olmalıydı: %q %q", apiErr.RequestID, apiErr.Body)
+	}
+}
+
+// TestRetryErrorAttempts yeniden denenen isteklerde her denemenin hatasının
+// saklandığını doğrular.
+func TestRetryErrorAttempts(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	srv.FailNext(trendyol.EndpointGetProductsKey, 1, http.StatusTooManyRequests, trendyol.ErrCodeRateLimit, "slow down")
+	srv.FailNext(trendyol.EndpointGetProductsKey, 1, http.StatusServiceUnavailable, trendyol.ErrCodeInternal, "down")
+	client := srv.Client(trendyol.WithRetryPolicy(trendyol.RetryPolicyFunc(func(req *trendyol.Request, attempt int, err error) (time.Duration, bool) {
+		return time.Millisecond, attempt < 2
+	})))
+
+	_, _, err := client.Products.List(context.Background(), 0, 10)
+	var retryErr *trendyol.RetryError
+	if !errors.As(err, &retryErr) || len(retryErr.Attempts) != 2 {
+		t.Fatalf("iki denemeli RetryError bekleniyordu: %v", err)
+	}
+	if !errors.Is(retryErr.Attempts[0], trendyol.ErrRateLimited) || !errors.Is(err, trendyol.ErrServer) {
+		t.Errorf("deneme hataları sırasıyla 429 ve 503 olmalıydı: %v", retryErr.Attempts)
+	}
+}
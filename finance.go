@@ -0,0 +1,291 @@
+package trendyol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MaxSettlementWindow is the longest startDate/endDate range the settlements
+// endpoint accepts in one query
+const MaxSettlementWindow = 15 * 24 * time.Hour
+
+// Settlement transaction types
+const (
+	TransactionSale               = "Sale"
+	TransactionReturn             = "Return"
+	TransactionDiscount           = "Discount"
+	TransactionDiscountCancel     = "DiscountCancel"
+	TransactionCoupon             = "Coupon"
+	TransactionCouponCancel       = "CouponCancel"
+	TransactionProvisionPositive  = "ProvisionPositive"
+	TransactionProvisionNegative  = "ProvisionNegative"
+	TransactionManualRefund       = "ManualRefund"
+	TransactionManualRefundCancel = "ManualRefundCancel"
+	TransactionTYDiscount         = "TYDiscount"
+	TransactionTYDiscountCancel   = "TYDiscountCancel"
+	TransactionTYCoupon           = "TYCoupon"
+	TransactionTYCouponCancel     = "TYCouponCancel"
+	TransactionPaymentOrder       = "PaymentOrder"
+)
+
+// SettlementTransactionTypes is queried when SettlementQuery.TransactionTypes
+// is empty
+var SettlementTransactionTypes = []string{
+	TransactionSale, TransactionReturn, TransactionDiscount, TransactionDiscountCancel,
+	TransactionCoupon, TransactionCouponCancel, TransactionProvisionPositive,
+	TransactionProvisionNegative, TransactionManualRefund, TransactionManualRefundCancel,
+	TransactionTYDiscount, TransactionTYDiscountCancel, TransactionTYCoupon,
+	TransactionTYCouponCancel, TransactionPaymentOrder,
+}
+
+// Other financial transaction types. The otherfinancials endpoint requires
+// one of them per query.
+const (
+	FinancialCashAdvance                = "CashAdvance"
+	FinancialWireTransfer               = "WireTransfer"
+	FinancialIncomingTransfer           = "IncomingTransfer"
+	FinancialReturnInvoice              = "ReturnInvoice"
+	FinancialCommissionAgreementInvoice = "CommissionAgreementInvoice"
+	FinancialPaymentOrder               = "PaymentOrder"
+	FinancialDeductionInvoices          = "DeductionInvoices"
+	FinancialSupplierFinancing          = "SupplierFinancing"
+	FinancialStoppage                   = "Stoppage"
+	FinancialItem                       = "FinancialItem"
+)
+
+// OtherFinancialTypes is queried when OtherFinancialQuery.TransactionTypes is empty
+var OtherFinancialTypes = []string{
+	FinancialCashAdvance, FinancialWireTransfer, FinancialIncomingTransfer, FinancialReturnInvoice,
+	FinancialCommissionAgreementInvoice, FinancialPaymentOrder, FinancialDeductionInvoices,
+	FinancialSupplierFinancing, FinancialStoppage, FinancialItem,
+}
+
+// OtherFinancial is a transaction not tied to an order line: payment orders,
+// deduction and commission invoices, transfers, supplier financing
+type OtherFinancial struct {
+	ID                            string  `json:"id"`
+	TransactionDate               int64   `json:"transactionDate"`
+	TransactionType               string  `json:"transactionType"`
+	ReceiptID                     int64   `json:"receiptId,omitempty"`
+	Description                   string  `json:"description"`
+	Debt                          float64 `json:"debt"`
+	Credit                        float64 `json:"credit"`
+	PaymentPeriod                 int     `json:"paymentPeriod,omitempty"`
+	CommissionRate                float64 `json:"commissionRate,omitempty"`
+	CommissionAmount              float64 `json:"commissionAmount,omitempty"`
+	CommissionInvoiceSerialNumber string  `json:"commissionInvoiceSerialNumber,omitempty"`
+	SellerRevenue                 float64 `json:"sellerRevenue,omitempty"`
+	OrderNumber                   string  `json:"orderNumber,omitempty"`
+	PaymentOrderID                int64   `json:"paymentOrderId,omitempty"`
+	PaymentDate                   int64   `json:"paymentDate,omitempty"`
+	Currency                      string  `json:"currency,omitempty"`
+}
+
+// Net returns the amount in the seller's favour, credit minus debt
+func (f OtherFinancial) Net() float64 {
+	return f.Credit - f.Debt
+}
+
+// SettlementQuery selects settlements over an arbitrary date range
+type SettlementQuery struct {
+	StartDate time.Time
+	EndDate   time.Time
+	// TransactionTypes limits the result to these types,
+	// SettlementTransactionTypes when empty. Each type is queried separately.
+	TransactionTypes []string
+	// PaymentOrderID limits the result to the rows paid out with one payment order
+	PaymentOrderID int64
+	// Size is the page size, default 500
+	Size int
+}
+
+// OtherFinancialQuery selects other financial transactions over an arbitrary
+// date range
+type OtherFinancialQuery struct {
+	StartDate time.Time
+	EndDate   time.Time
+	// TransactionTypes limits the result to these types, OtherFinancialTypes
+	// when empty. Each type is queried separately.
+	TransactionTypes []string
+	// PaymentOrderID limits the result to the rows of one payment order
+	PaymentOrderID int64
+	// Size is the page size, default 500
+	Size int
+}
+
+// financeFetcher fetches one page of a finance endpoint for a single window
+// and transaction type
+type financeFetcher[T any] func(ctx context.Context, from, to time.Time, transactionType string, page, size int) ([]T, *PaginatedResponse, error)
+
+// walkFinanceRange splits [start, end] into windows of at most
+// MaxSettlementWindow and walks every page of each window and transaction
+// type. Rows whose non-empty id was already seen are skipped; rows without an
+// id are always passed to fn. The endpoints require a transaction type, so
+// types must not be empty.
+func walkFinanceRange[T any](ctx context.Context, start, end time.Time, types []string, size int, fetch financeFetcher[T], id func(T) string, fn func(T) error) error {
+	if end.Before(start) {
+		return fmt.Errorf("finance query end date %s is before start date %s", end, start)
+	}
+	if size <= 0 {
+		size = 500
+	}
+	if len(types) == 0 {
+		return fmt.Errorf("finance query needs at least one transaction type")
+	}
+
+	seen := map[string]bool{}
+	emit := func(row T) error {
+		if key := id(row); key != "" {
+			if seen[key] {
+				return nil
+			}
+			seen[key] = true
+		}
+		return fn(row)
+	}
+	for from := start; !from.After(end); from = from.Add(MaxSettlementWindow) {
+		to := from.Add(MaxSettlementWindow - time.Millisecond)
+		if to.After(end) {
+			to = end
+		}
+		for _, txType := range types {
+			err := walkPages(ctx, 0, func(ctx context.Context, page int) ([]T, *PaginatedResponse, error) {
+				return fetch(ctx, from, to, txType, page, size)
+			}, emit)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ForEachSettlementInRange splits the query range into windows of at most
+// MaxSettlementWindow, walks every page of each window and calls fn once per
+// settlement. Rows with the same ID returned by more than one window or
+// transaction type are passed only once.
+func (s *financeService) ForEachSettlementInRange(ctx context.Context, q SettlementQuery, fn func(Settlement) error) error {
+	types := q.TransactionTypes
+	if len(types) == 0 {
+		types = SettlementTransactionTypes
+	}
+	fetch := func(ctx context.Context, from, to time.Time, txType string, page, size int) ([]Settlement, *PaginatedResponse, error) {
+		return s.getSettlements(ctx, financeValues(from, to, txType, q.PaymentOrderID, page, size))
+	}
+	return walkFinanceRange(ctx, q.StartDate, q.EndDate, types, q.Size, fetch,
+		func(s Settlement) string { return s.ID }, fn)
+}
+
+// ListSettlementsInRange collects every settlement matching q, see
+// ForEachSettlementInRange
+func (s *financeService) ListSettlementsInRange(ctx context.Context, q SettlementQuery) ([]Settlement, error) {
+	var rows []Settlement
+	err := s.ForEachSettlementInRange(ctx, q, func(row Settlement) error {
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GetOtherFinancials returns one page of a single window and transaction type
+func (s *financeService) GetOtherFinancials(ctx context.Context, startDate, endDate time.Time, transactionType string, page, size int) ([]OtherFinancial, *PaginatedResponse, error) {
+	return s.getOtherFinancials(ctx, financeValues(startDate, endDate, transactionType, 0, page, size))
+}
+
+// ForEachOtherFinancial walks the query range like ForEachSettlementInRange,
+// once per transaction type
+func (s *financeService) ForEachOtherFinancial(ctx context.Context, q OtherFinancialQuery, fn func(OtherFinancial) error) error {
+	types := q.TransactionTypes
+	if len(types) == 0 {
+		types = OtherFinancialTypes
+	}
+	fetch := func(ctx context.Context, from, to time.Time, txType string, page, size int) ([]OtherFinancial, *PaginatedResponse, error) {
+		return s.getOtherFinancials(ctx, financeValues(from, to, txType, q.PaymentOrderID, page, size))
+	}
+	return walkFinanceRange(ctx, q.StartDate, q.EndDate, types, q.Size, fetch,
+		func(f OtherFinancial) string { return f.ID }, fn)
+}
+
+// ListOtherFinancials collects every transaction matching q, see
+// ForEachOtherFinancial
+func (s *financeService) ListOtherFinancials(ctx context.Context, q OtherFinancialQuery) ([]OtherFinancial, error) {
+	var rows []OtherFinancial
+	err := s.ForEachOtherFinancial(ctx, q, func(row OtherFinancial) error {
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// financeValues builds the query shared by the finance endpoints
+func financeValues(startDate, endDate time.Time, transactionType string, paymentOrderID int64, page, size int) url.Values {
+	query := url.Values{
+		"startDate": []string{strconv.FormatInt(startDate.UnixMilli(), 10)},
+		"endDate":   []string{strconv.FormatInt(endDate.UnixMilli(), 10)},
+		"page":      []string{strconv.Itoa(page)},
+		"size":      []string{strconv.Itoa(size)},
+	}
+	if transactionType != "" {
+		query.Set("transactionType", transactionType)
+	}
+	if paymentOrderID != 0 {
+		query.Set("paymentOrderId", strconv.FormatInt(paymentOrderID, 10))
+	}
+	return query
+}
+
+func (s *financeService) getSettlements(ctx context.Context, query url.Values) ([]Settlement, *PaginatedResponse, error) {
+	type response struct {
+		Content []Settlement `json:"content"`
+		PaginatedResponse
+	}
+
+	result := &response{}
+	req := &Request{
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetSettlementsKey,
+		Path:     s.client.resolve(EndpointGetSettlementsKey, s.client.sellerID),
+		Query:    query,
+		Result:   result,
+	}
+
+	err := s.client.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result.Content, &result.PaginatedResponse, nil
+}
+
+func (s *financeService) getOtherFinancials(ctx context.Context, query url.Values) ([]OtherFinancial, *PaginatedResponse, error) {
+	type response struct {
+		Content []OtherFinancial `json:"content"`
+		PaginatedResponse
+	}
+
+	result := &response{}
+	req := &Request{
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetOtherFinancialsKey,
+		Path:     s.client.resolve(EndpointGetOtherFinancialsKey, s.client.sellerID),
+		Query:    query,
+		Result:   result,
+	}
+
+	err := s.client.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result.Content, &result.PaginatedResponse, nil
+}
@@ -0,0 +1,134 @@
+package trendyol_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestSettlementsInRange uzun bir aralığın 15 günlük pencerelere bölündüğünü,
+// işlem türüne göre süzüldüğünü, pencere sınırındaki kayıtların bir kez
+// döndüğünü ve ID'siz aynı kayıtların ayıklanmadığını doğrular.
+func TestSettlementsInRange(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 45)
+	boundary := start.Add(trendyol.MaxSettlementWindow)
+	srv.AddSettlements(
+		trendyol.Settlement{ID: "1", SettlementDate: start.UnixMilli(), TransactionType: trendyol.TransactionSale, OrderNumber: "R-1", Amount: 10},
+		trendyol.Settlement{ID: "2", SettlementDate: boundary.UnixMilli(), TransactionType: trendyol.TransactionSale, OrderNumber: "R-2", Amount: 20},
+		trendyol.Settlement{ID: "3", SettlementDate: start.AddDate(0, 0, 20).UnixMilli(), TransactionType: trendyol.TransactionReturn, OrderNumber: "R-2", Amount: -20},
+		trendyol.Settlement{ID: "4", SettlementDate: start.AddDate(0, 0, 40).UnixMilli(), TransactionType: trendyol.TransactionCoupon, OrderNumber: "R-3", Amount: -5},
+		trendyol.Settlement{ID: "5", SettlementDate: end.UnixMilli(), TransactionType: trendyol.TransactionSale, OrderNumber: "R-4", Amount: 30},
+		trendyol.Settlement{ID: "6", SettlementDate: end.Add(time.Hour).UnixMilli(), TransactionType: trendyol.TransactionSale, OrderNumber: "R-5", Amount: 40},
+	)
+	// Identical rows without an ID are distinct transactions, e.g. two units
+	// of the same line
+	twin := trendyol.Settlement{SettlementDate: start.AddDate(0, 0, 5).UnixMilli(), TransactionType: trendyol.TransactionSale, OrderNumber: "R-6", Amount: 15}
+	srv.AddSettlements(twin, twin)
+
+	rows, err := client.Finance.ListSettlementsInRange(ctx, trendyol.SettlementQuery{StartDate: start, EndDate: end})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 7 {
+		t.Fatalf("7 kayıt beklendi, %d geldi: %+v", len(rows), rows)
+	}
+	// Every window is queried once per transaction type
+	if calls := srv.Calls(trendyol.EndpointGetSettlementsKey); len(calls) != 4*len(trendyol.SettlementTransactionTypes) {
+		t.Errorf("45 günlük aralık 4 pencereye bölünüp her tür ayrı sorgulanmalıydı, %d istek atıldı", len(calls))
+	}
+	for _, call := range srv.Calls(trendyol.EndpointGetSettlementsKey) {
+		if call.Query.Get("transactionType") == "" {
+			t.Fatalf("işlem türü boş gönderilmemeli: %v", call.Query)
+		}
+	}
+
+	var ids []string
+	err = client.Finance.ForEachSettlementInRange(ctx, trendyol.SettlementQuery{
+		StartDate:        start,
+		EndDate:          end,
+		TransactionTypes: []string{trendyol.TransactionReturn, trendyol.TransactionCoupon},
+		Size:             1,
+	}, func(s trendyol.Settlement) error {
+		ids = append(ids, s.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != "3" || ids[1] != "4" {
+		t.Errorf("yalnızca iade ve kupon kayıtları beklendi: %v", ids)
+	}
+
+	_, err = client.Finance.ListSettlementsInRange(ctx, trendyol.SettlementQuery{StartDate: end, EndDate: start})
+	if err == nil {
+		t.Error("ters aralık reddedilmeliydi")
+	}
+}
+
+// TestOtherFinancials tür verilmediğinde tüm diğer finansal işlem türlerinin
+// ayrı ayrı sorgulandığını ve ödeme talimatı filtresinin uygulandığını doğrular.
+func TestOtherFinancials(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 20)
+	at := func(day int) int64 { return start.AddDate(0, 0, day).UnixMilli() }
+	srv.AddOtherFinancials(
+		trendyol.OtherFinancial{ID: "PO-1", TransactionDate: at(1), TransactionType: trendyol.FinancialPaymentOrder, Credit: 900, PaymentOrderID: 77},
+		trendyol.OtherFinancial{ID: "DED-1", TransactionDate: at(2), TransactionType: trendyol.FinancialDeductionInvoices, Debt: 120, PaymentOrderID: 77},
+		trendyol.OtherFinancial{ID: "COM-1", TransactionDate: at(18), TransactionType: trendyol.FinancialCommissionAgreementInvoice, Debt: 45, CommissionInvoiceSerialNumber: "DCF2025000001"},
+		trendyol.OtherFinancial{ID: "SF-1", TransactionDate: at(30), TransactionType: trendyol.FinancialSupplierFinancing, Credit: 300},
+	)
+	srv.AddSettlements(
+		trendyol.Settlement{ID: "S-1", SettlementDate: at(1), TransactionType: trendyol.TransactionSale, OrderNumber: "P-1", Amount: 500, PaymentOrderID: 77},
+		trendyol.Settlement{ID: "S-2", SettlementDate: at(3), TransactionType: trendyol.TransactionSale, OrderNumber: "P-2", Amount: 250, PaymentOrderID: 78},
+	)
+
+	rows, err := client.Finance.ListOtherFinancials(ctx, trendyol.OtherFinancialQuery{StartDate: start, EndDate: end})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var net float64
+	for _, r := range rows {
+		net += r.Net()
+	}
+	if len(rows) != 3 || net != 735 {
+		t.Fatalf("aralıktaki 3 işlem beklendi (net 735): %d, %v", len(rows), net)
+	}
+	if calls := srv.Calls(trendyol.EndpointGetOtherFinancialsKey); len(calls) != 2*len(trendyol.OtherFinancialTypes) {
+		t.Errorf("her pencere ve tür için bir istek beklendi, %d istek atıldı", len(calls))
+	}
+
+	rows, err = client.Finance.ListOtherFinancials(ctx, trendyol.OtherFinancialQuery{
+		StartDate:        start,
+		EndDate:          end,
+		TransactionTypes: []string{trendyol.FinancialPaymentOrder, trendyol.FinancialDeductionInvoices},
+		PaymentOrderID:   77,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	settled, err := client.Finance.ListSettlementsInRange(ctx, trendyol.SettlementQuery{StartDate: start, EndDate: end, PaymentOrderID: 77})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || len(settled) != 1 || settled[0].ID != "S-1" {
+		t.Errorf("ödeme talimatı 77'ye ait kayıtlar beklendi: %+v %+v", rows, settled)
+	}
+
+	if _, _, err := client.Finance.GetOtherFinancials(ctx, start, end, "", 0, 10); err == nil {
+		t.Error("işlem türü olmadan sorgu reddedilmeliydi")
+	}
+}
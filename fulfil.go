@@ -0,0 +1,327 @@
+package trendyol
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Fulfilment steps in the order they are run
+const (
+	FulfilStepPicking     = "picking"
+	FulfilStepInvoicing   = "invoicing"
+	FulfilStepInvoiceLink = "invoiceLink"
+	FulfilStepBoxInfo     = "boxInfo"
+	FulfilStepLabel       = "label"
+)
+
+// FulfilmentPlan describes how a shipment package is fulfilled
+type FulfilmentPlan struct {
+	InvoiceNumber string  // required, sent with the Invoiced status
+	InvoiceLink   string  // optional, skipped when empty
+	BoxQuantity   int     // defaults to 1
+	Deci          float64 // required, total deci of the boxes
+	LabelFormat   string  // defaults to "ZPL"
+}
+
+// FulfilmentState records the progress of a package so that an interrupted
+// run can be resumed
+type FulfilmentState struct {
+	PackageID           int64    `json:"packageId"`
+	CargoTrackingNumber int64    `json:"cargoTrackingNumber,omitempty"`
+	Completed           []string `json:"completed,omitempty"`
+	// Pending is the step whose request was sent but not confirmed; on resume
+	// its outcome is checked against the API before it is sent again.
+	Pending string `json:"pending,omitempty"`
+}
+
+// Done reports whether step was completed
+func (s FulfilmentState) Done(step string) bool {
+	for _, c := range s.Completed {
+		if c == step {
+			return true
+		}
+	}
+	return false
+}
+
+// FulfilmentStore persists fulfilment progress per package
+type FulfilmentStore interface {
+	Load(ctx context.Context, packageID int64) (FulfilmentState, error)
+	Save(ctx context.Context, state FulfilmentState) error
+}
+
+// memoryFulfilmentStore keeps fulfilment progress in memory
+type memoryFulfilmentStore struct {
+	mu     sync.Mutex
+	states map[int64]FulfilmentState
+}
+
+// NewMemoryFulfilmentStore returns a FulfilmentStore that lives as long as the process
+func NewMemoryFulfilmentStore() FulfilmentStore {
+	return &memoryFulfilmentStore{states: map[int64]FulfilmentState{}}
+}
+
+func (s *memoryFulfilmentStore) Load(ctx context.Context, packageID int64) (FulfilmentState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.states[packageID]
+	if !ok {
+		return FulfilmentState{PackageID: packageID}, nil
+	}
+	st.Completed = append([]string(nil), st.Completed...)
+	return st, nil
+}
+
+func (s *memoryFulfilmentStore) Save(ctx context.Context, state FulfilmentState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state.Completed = append([]string(nil), state.Completed...)
+	s.states[state.PackageID] = state
+	return nil
+}
+
+// fileFulfilmentStore keeps one JSON file per package in a directory
+type fileFulfilmentStore struct {
+	dir string
+}
+
+// NewFileFulfilmentStore returns a FulfilmentStore that writes <dir>/<packageID>.json
+func NewFileFulfilmentStore(dir string) FulfilmentStore {
+	return &fileFulfilmentStore{dir: dir}
+}
+
+func (s *fileFulfilmentStore) path(packageID int64) string {
+	return filepath.Join(s.dir, strconv.FormatInt(packageID, 10)+".json")
+}
+
+func (s *fileFulfilmentStore) Load(ctx context.Context, packageID int64) (FulfilmentState, error) {
+	st := FulfilmentState{PackageID: packageID}
+	data, err := os.ReadFile(s.path(packageID))
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, err
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return st, fmt.Errorf("failed to decode fulfilment state of package %d: %w", packageID, err)
+	}
+	return st, nil
+}
+
+func (s *fileFulfilmentStore) Save(ctx context.Context, state FulfilmentState) error {
+	return writeFileAtomic(s.path(state.PackageID), state)
+}
+
+// FulfilOption configures a Fulfiller
+type FulfilOption func(*Fulfiller)
+
+// WithFulfilmentStore sets where fulfilment progress is persisted
+func WithFulfilmentStore(store FulfilmentStore) FulfilOption {
+	return func(f *Fulfiller) {
+		f.store = store
+	}
+}
+
+// Fulfiller runs the seller side of shipping a package: picking, invoicing,
+// sending the invoice link, setting box info, creating the common label and
+// fetching it. Progress is saved after every step.
+type Fulfiller struct {
+	client *Client
+	store  FulfilmentStore
+}
+
+// NewFulfiller creates a Fulfiller using client
+func NewFulfiller(client *Client, opts ...FulfilOption) *Fulfiller {
+	f := &Fulfiller{client: client, store: NewMemoryFulfilmentStore()}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Fulfil runs the remaining steps of plan for the package and returns the
+// label content. Steps recorded as completed by an earlier run are skipped,
+// so calling Fulfil again after a failure resumes where it stopped.
+func (f *Fulfiller) Fulfil(ctx context.Context, packageID int64, plan FulfilmentPlan) ([]byte, error) {
+	if plan.InvoiceNumber == "" {
+		return nil, fmt.Errorf("invoice number is required to fulfil package %d", packageID)
+	}
+	if plan.Deci <= 0 {
+		return nil, fmt.Errorf("deci must be positive to fulfil package %d", packageID)
+	}
+	if plan.BoxQuantity <= 0 {
+		plan.BoxQuantity = 1
+	}
+	if plan.LabelFormat == "" {
+		plan.LabelFormat = "ZPL"
+	}
+
+	st, err := f.store.Load(ctx, packageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fulfilment state: %w", err)
+	}
+	st.PackageID = packageID
+
+	order, err := f.order(ctx, packageID)
+	if err != nil {
+		return nil, err
+	}
+	if order.CargoTrackingNumber != 0 {
+		st.CargoTrackingNumber = order.CargoTrackingNumber
+	}
+	// Checked before any step so that the package is not left half processed
+	if st.CargoTrackingNumber == 0 {
+		return nil, fmt.Errorf("package %d has no cargo tracking number to create a label for", packageID)
+	}
+
+	// run executes step unless it is already done. Pending is saved before
+	// the request so that a crash in between is detected on resume.
+	run := func(step string, fn func(resumed bool) error) error {
+		if st.Done(step) {
+			return nil
+		}
+		resumed := st.Pending == step
+		st.Pending = step
+		if err := f.store.Save(ctx, st); err != nil {
+			return fmt.Errorf("failed to save fulfilment state: %w", err)
+		}
+		if err := fn(resumed); err != nil {
+			return fmt.Errorf("fulfilment step %s of package %d failed: %w", step, packageID, err)
+		}
+		st.Completed = append(st.Completed, step)
+		st.Pending = ""
+		if err := f.store.Save(ctx, st); err != nil {
+			return fmt.Errorf("failed to save fulfilment state: %w", err)
+		}
+		return nil
+	}
+
+	err = run(FulfilStepPicking, func(bool) error {
+		if reached(order.Status, StatusPicking) {
+			// Already moved on, either by an earlier run or by hand
+			return nil
+		}
+		if err := f.client.Orders.MarkPicking(ctx, order); err != nil {
+			return err
+		}
+		order.Status = StatusPicking
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = run(FulfilStepInvoicing, func(bool) error {
+		if reached(order.Status, StatusInvoiced) {
+			return nil
+		}
+		if err := f.client.Orders.MarkInvoiced(ctx, order, plan.InvoiceNumber); err != nil {
+			return err
+		}
+		order.Status = StatusInvoiced
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.InvoiceLink != "" {
+		err = run(FulfilStepInvoiceLink, func(resumed bool) error {
+			err := f.client.Orders.SendInvoiceLink(ctx, packageID, plan.InvoiceLink)
+			if resumed && errors.Is(err, ErrConflict) {
+				// The link of the interrupted run was accepted
+				return nil
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = run(FulfilStepBoxInfo, func(bool) error {
+		return f.client.Orders.UpdateBoxInfo(ctx, packageID, plan.BoxQuantity, plan.Deci)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tracking := strconv.FormatInt(st.CargoTrackingNumber, 10)
+
+	var raw []byte
+	err = run(FulfilStepLabel, func(resumed bool) error {
+		if resumed {
+			if raw, err = f.client.CommonLabel.GetLabel(ctx, tracking); err == nil {
+				return nil
+			}
+		}
+		return f.client.CommonLabel.CreateLabel(ctx, tracking, CommonLabelRequest{Format: plan.LabelFormat, BoxQuantity: plan.BoxQuantity})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if raw == nil {
+		if raw, err = f.client.CommonLabel.GetLabel(ctx, tracking); err != nil {
+			return nil, fmt.Errorf("failed to fetch label of package %d: %w", packageID, err)
+		}
+	}
+	return ExtractLabel(raw), nil
+}
+
+// fulfilmentProgress orders the statuses a package passes while it is fulfilled
+var fulfilmentProgress = []PackageStatus{StatusCreated, StatusPicking, StatusInvoiced, StatusShipped, StatusDelivered}
+
+// reached reports whether a package in status has already passed target on
+// the fulfilment path. Statuses off the path, such as Cancelled, never do.
+func reached(status, target PackageStatus) bool {
+	at, want := -1, -1
+	for i, s := range fulfilmentProgress {
+		if s == status {
+			at = i
+		}
+		if s == target {
+			want = i
+		}
+	}
+	return at >= 0 && at >= want
+}
+
+func (f *Fulfiller) order(ctx context.Context, packageID int64) (Order, error) {
+	orders, _, err := f.client.Orders.List(ctx, ListOrdersOptions{ShipmentPackageIDs: []int64{packageID}, Size: 1})
+	if err != nil {
+		return Order{}, err
+	}
+	for _, o := range orders {
+		if o.ID == packageID {
+			return o, nil
+		}
+	}
+	return Order{}, fmt.Errorf("shipment package %d not found", packageID)
+}
+
+// ExtractLabel returns the label content of a getCommonLabel response, or the
+// raw body when it is not in the documented JSON shape
+func ExtractLabel(raw []byte) []byte {
+	var resp struct {
+		Data []struct {
+			Label string `json:"label"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil || len(resp.Data) == 0 {
+		return raw
+	}
+	labels := make([]string, len(resp.Data))
+	for i, d := range resp.Data {
+		labels[i] = d.Label
+	}
+	return []byte(strings.Join(labels, "\n"))
+}
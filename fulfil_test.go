@@ -0,0 +1,124 @@
+package trendyol_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestFulfil adımların sırayla çalıştırıldığını ve etiketin döndüğünü doğrular.
+func TestFulfil(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	order := srv.AddOrder(trendyol.Order{OrderNumber: "FUL-1", Lines: []trendyol.OrderLine{{Quantity: 1, Barcode: "F-1"}}})
+
+	f := trendyol.NewFulfiller(srv.Client())
+	label, err := f.Fulfil(ctx, order.ID, trendyol.FulfilmentPlan{
+		InvoiceNumber: "INV-1",
+		InvoiceLink:   "https://example.com/inv-1.pdf",
+		BoxQuantity:   2,
+		Deci:          3.5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(label), "FUL-1") || strings.Count(string(label), "^XA") != 2 {
+		t.Fatalf("beklenmeyen etiket: %q", label)
+	}
+
+	got, _ := srv.Order(order.ID)
+	if got.Status != trendyol.StatusInvoiced {
+		t.Errorf("statü Invoiced olmalıydı: %s", got.Status)
+	}
+	if link, _ := srv.InvoiceLink(order.ID); link != "https://example.com/inv-1.pdf" {
+		t.Errorf("fatura linki gönderilmedi: %q", link)
+	}
+	if n, _ := srv.BoxQuantity(order.ID); n != 2 {
+		t.Errorf("koli adedi 2 olmalıydı: %d", n)
+	}
+}
+
+// TestFulfilResume kesintiye uğrayan bir çalışmanın tamamlanan adımları
+// tekrarlamadan kaldığı yerden devam ettiğini doğrular.
+func TestFulfilResume(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	order := srv.AddOrder(trendyol.Order{Lines: []trendyol.OrderLine{{Quantity: 1, Barcode: "F-2"}}})
+
+	// The invoice link reaches the server but the caller never sees the answer
+	crash := errors.New("connection lost")
+	crashed := false
+	client := srv.Client(trendyol.WithMiddleware(func(next trendyol.RoundTripFunc) trendyol.RoundTripFunc {
+		return func(ctx context.Context, req *trendyol.Request) error {
+			err := next(ctx, req)
+			if req.Endpoint == trendyol.EndpointSendInvoiceLinkKey && !crashed {
+				crashed = true
+				return crash
+			}
+			return err
+		}
+	}))
+
+	store := trendyol.NewFileFulfilmentStore(t.TempDir())
+	plan := trendyol.FulfilmentPlan{InvoiceNumber: "INV-2", InvoiceLink: "https://example.com/inv-2.pdf", Deci: 1}
+
+	if _, err := trendyol.NewFulfiller(client, trendyol.WithFulfilmentStore(store)).Fulfil(ctx, order.ID, plan); !errors.Is(err, crash) {
+		t.Fatalf("ilk çalışma kesilmeliydi: %v", err)
+	}
+	st, err := store.Load(ctx, order.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.Done(trendyol.FulfilStepInvoicing) || st.Pending != trendyol.FulfilStepInvoiceLink {
+		t.Fatalf("beklenmeyen durum: %+v", st)
+	}
+
+	label, err := trendyol.NewFulfiller(client, trendyol.WithFulfilmentStore(store)).Fulfil(ctx, order.ID, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(label) == 0 {
+		t.Fatal("etiket boş olmamalıydı")
+	}
+	if n := len(srv.Calls(trendyol.EndpointUpdatePackageStatusKey)); n != 2 {
+		t.Errorf("statü güncellemesi tekrarlanmamalıydı: %d istek", n)
+	}
+	if n := len(srv.Calls(trendyol.EndpointCreateCommonLabelKey)); n != 1 {
+		t.Errorf("etiket bir kez oluşturulmalıydı: %d istek", n)
+	}
+
+	st, _ = store.Load(ctx, order.ID)
+	if !st.Done(trendyol.FulfilStepLabel) || st.Pending != "" {
+		t.Errorf("tüm adımlar tamamlanmış olmalıydı: %+v", st)
+	}
+}
+
+// TestFulfilNoTrackingNumber kargo takip numarası olmayan paketin hiçbir adım
+// çalıştırılmadan reddedildiğini doğrular.
+func TestFulfilNoTrackingNumber(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+	order := srv.AddOrder(trendyol.Order{Lines: []trendyol.OrderLine{{Quantity: 1, Barcode: "F-3"}}})
+	if err := client.Orders.UpdateTrackingNumber(ctx, order.ID, "0"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := trendyol.NewFulfiller(client).Fulfil(ctx, order.ID, trendyol.FulfilmentPlan{InvoiceNumber: "INV-3", Deci: 1})
+	if err == nil || !strings.Contains(err.Error(), "tracking number") {
+		t.Fatalf("takip numarası hatası beklendi: %v", err)
+	}
+	if n := len(srv.Calls(trendyol.EndpointUpdatePackageStatusKey, trendyol.EndpointUpdateBoxInfoKey)); n != 0 {
+		t.Errorf("hiçbir adım çalışmamalıydı: %d istek", n)
+	}
+	if got, _ := srv.Order(order.ID); got.Status != trendyol.StatusCreated {
+		t.Errorf("statü değişmemeliydi: %s", got.Status)
+	}
+}
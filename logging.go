@@ -0,0 +1,167 @@
+package trendyol
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// redacted replaces sensitive values in log output
+const redacted = "[REDACTED]"
+
+// maxLoggedBody caps non-JSON response bodies written to debug logs
+const maxLoggedBody = 1024
+
+// WithLogger enables structured logging of every request attempt. Attempts are
+// logged at Debug level, failed attempts at Warn and exhausted retries at Error.
+// Request and response bodies are only included at Debug level, with customer
+// emails, phone numbers, identity/tax numbers and credentials redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+func (c *Client) logAttempt(ctx context.Context, req *Request, attempt int, latency, backoff time.Duration, body []byte, err error) {
+	if c.logger == nil {
+		return
+	}
+	level, msg := slog.LevelDebug, "trendyol request"
+	if err != nil {
+		level, msg = slog.LevelWarn, "trendyol request attempt failed"
+	}
+	if !c.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("endpoint", req.Endpoint),
+		slog.String("method", req.Method),
+		slog.String("path", req.Path),
+		slog.Int("attempt", attempt),
+		slog.Int("status", req.StatusCode),
+		slog.Duration("latency", latency),
+	}
+	if backoff > 0 {
+		attrs = append(attrs, slog.Duration("backoff", backoff))
+	}
+	if id := batchRequestID(req); id != "" {
+		attrs = append(attrs, slog.String("batchRequestId", id))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	if c.logger.Enabled(ctx, slog.LevelDebug) {
+		if len(req.Header) > 0 {
+			attrs = append(attrs, slog.Any("header", redactHeader(req.Header)))
+		}
+		if req.Body != nil {
+			attrs = append(attrs, slog.Any("request", redactValue(req.Body)))
+		}
+		if len(body) > 0 && !req.RawResponse {
+			attrs = append(attrs, slog.Any("response", redactJSON(body)))
+		}
+	}
+	c.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+func (c *Client) logGiveUp(ctx context.Context, req *Request, attempts int, err error) {
+	if c.logger == nil {
+		return
+	}
+	c.logger.LogAttrs(ctx, slog.LevelError, "trendyol request failed",
+		slog.String("endpoint", req.Endpoint),
+		slog.String("method", req.Method),
+		slog.String("path", req.Path),
+		slog.Int("attempts", attempts),
+		slog.String("error", err.Error()),
+	)
+}
+
+// batchRequestID extracts the batch ID from batch responses
+func batchRequestID(req *Request) string {
+	switch r := req.Result.(type) {
+	case *BatchResponse:
+		return r.BatchRequestID
+	case *BatchStatusResponse:
+		return r.BatchRequestID
+	}
+	return ""
+}
+
+// LogValue implements slog.LogValuer so customer contact and identity
+// details of an order are never written to logs
+func (o Order) LogValue() slog.Value {
+	return slog.AnyValue(redactValue(o))
+}
+
+// LogValue implements slog.LogValuer and redacts the phone number
+func (a OrderAddress) LogValue() slog.Value {
+	return slog.AnyValue(redactValue(a))
+}
+
+// sensitiveSuffixes are matched against the end of lower-cased keys, so that
+// customerEmail is redacted but isPhoneNumber or phoneVerified are not
+var sensitiveSuffixes = []string{"email", "phone", "phonenumber", "gsm", "identitynumber", "taxnumber", "authorization", "password", "apisecret"}
+
+// sensitiveKey reports whether a JSON key or header holds personal data
+func sensitiveKey(key string) bool {
+	k := strings.ToLower(key)
+	for _, s := range sensitiveSuffixes {
+		if strings.HasSuffix(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for k := range out {
+		if sensitiveKey(k) {
+			out[k] = []string{redacted}
+		}
+	}
+	return out
+}
+
+// redactValue returns v as a generic JSON tree with sensitive fields replaced
+func redactValue(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return redacted
+	}
+	return redactJSON(data)
+}
+
+func redactJSON(data []byte) interface{} {
+	var tree interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		if len(data) > maxLoggedBody {
+			data = data[:maxLoggedBody]
+		}
+		return string(data)
+	}
+	return redactTree(tree)
+}
+
+func redactTree(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if _, flag := val.(bool); sensitiveKey(k) && !flag && val != nil && val != "" {
+				t[k] = redacted
+				continue
+			}
+			t[k] = redactTree(val)
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = redactTree(t[i])
+		}
+	}
+	return v
+}
@@ -0,0 +1,104 @@
+package trendyol_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestLoggerRedaction deneme başına log kaydı üretildiğini ve müşteri e-posta,
+// telefon ve kimlik numarasının loglara yazılmadığını doğrular.
+func TestLoggerRedaction(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	srv.AddOrder(trendyol.Order{
+		OrderNumber:     "ORD-1",
+		CustomerEmail:   "ayse@example.com",
+		IdentityNumber:  "12345678901",
+		ShipmentAddress: &trendyol.OrderAddress{FullName: "Ayşe Yılmaz", Phone: 5551234567, City: "İstanbul"},
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := srv.Client(trendyol.WithLogger(logger))
+
+	if _, _, err := client.Orders.List(context.Background(), trendyol.ListOrdersOptions{}); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	out := buf.String()
+	for _, secret := range []string{"ayse@example.com", "12345678901", "5551234567", "test-api-secret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log hassas veri içeriyor: %s", secret)
+		}
+	}
+	var rec map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &rec); err != nil {
+		t.Fatalf("log satırı çözülemedi: %v\n%s", err, out)
+	}
+	if rec["endpoint"] != trendyol.EndpointGetOrdersKey || rec["status"] != float64(200) || rec["attempt"] != float64(1) {
+		t.Errorf("beklenmeyen log alanları: %v", rec)
+	}
+	if !strings.Contains(out, "İstanbul") {
+		t.Errorf("hassas olmayan alanlar loglanmalıydı: %s", out)
+	}
+}
+
+// TestLoggerRedactionFlags telefonla ilgili bayrak alanlarının maskelenmediğini
+// doğrular.
+func TestLoggerRedactionFlags(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	order := srv.AddOrder(trendyol.Order{OrderNumber: "ORD-2", Lines: []trendyol.OrderLine{{Barcode: "B-1", Quantity: 1}}})
+	srv.SetOrderStatus(order.ID, trendyol.StatusInvoiced)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := srv.Client(trendyol.WithLogger(logger))
+
+	req := trendyol.AlternativeDeliveryRequest{IsPhoneNumber: true, TrackingInfo: "TRK-1"}
+	if err := client.Orders.AlternativeDelivery(context.Background(), order.ID, req); err != nil {
+		t.Fatalf("AlternativeDelivery: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, `"isPhoneNumber":true`) {
+		t.Errorf("isPhoneNumber maskelenmemeliydi: %s", out)
+	}
+}
+
+// TestLoggerRetryAttempts başarısız denemelerin Warn, tükenen denemelerin Error
+// seviyesinde ve bekleme süresiyle loglandığını doğrular.
+func TestLoggerRetryAttempts(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	srv.FailNext(trendyol.EndpointGetProductsKey, 2, http.StatusInternalServerError, trendyol.ErrCodeInternal, "boom")
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	client := srv.Client(trendyol.WithRetryConfig(1, time.Millisecond), trendyol.WithLogger(logger))
+
+	if _, _, err := client.Products.List(context.Background(), 0, 10); err == nil {
+		t.Fatal("hata bekleniyordu")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("2 uyarı ve 1 hata kaydı beklendi, gelen %d:\n%s", len(lines), buf.String())
+	}
+	var first, last map[string]interface{}
+	_ = json.Unmarshal([]byte(lines[0]), &first)
+	_ = json.Unmarshal([]byte(lines[2]), &last)
+	if first["level"] != "WARN" || first["backoff"] == nil || first["status"] != float64(500) {
+		t.Errorf("ilk deneme kaydı beklenmedik: %v", first)
+	}
+	if last["level"] != "ERROR" || last["attempts"] != float64(2) {
+		t.Errorf("son kayıt beklenmedik: %v", last)
+	}
+}
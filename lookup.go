@@ -0,0 +1,170 @@
+package trendyol
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// barcodeLookupGroupSize is the number of barcodes sent comma-separated in
+	// one filterProducts request by GetByBarcodes
+	barcodeLookupGroupSize = 20
+	// barcodeLookupConcurrency bounds the parallel requests of GetByBarcodes
+	barcodeLookupConcurrency = 4
+)
+
+// ProductNotFoundError is returned when no product has the requested barcode.
+// It matches ErrNotFound.
+type ProductNotFoundError struct {
+	Barcode string
+}
+
+func (e *ProductNotFoundError) Error() string {
+	return "product not found with barcode: " + e.Barcode
+}
+
+// Is reports whether target is ErrNotFound
+func (e *ProductNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// findByBarcodes runs one filterProducts request for the given barcodes and
+// returns the products whose barcode was asked for
+func (s *productService) findByBarcodes(ctx context.Context, barcodes []string) ([]Product, error) {
+	type response struct {
+		Content []Product `json:"content"`
+	}
+
+	result := &response{}
+	req := &Request{
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetProductsKey,
+		Path:     s.client.resolve(EndpointGetProductsKey, s.client.sellerID),
+		Query: url.Values{
+			"barcode": []string{strings.Join(barcodes, ",")},
+			"size":    []string{strconv.Itoa(len(barcodes))},
+		},
+		Result: result,
+	}
+	if err := s.client.Do(ctx, req); err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(barcodes))
+	for _, bc := range barcodes {
+		wanted[bc] = true
+	}
+	var found []Product
+	for _, p := range result.Content {
+		if wanted[p.Barcode] {
+			found = append(found, p)
+		}
+	}
+	return found, nil
+}
+
+func (s *productService) GetByBarcode(ctx context.Context, barcode string) (*Product, error) {
+	found, err := s.findByBarcodes(ctx, []string{barcode})
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, &ProductNotFoundError{Barcode: barcode}
+	}
+	return &found[0], nil
+}
+
+// GetByBarcodes looks up many products with a bounded number of parallel
+// requests. Barcodes are queried in comma-separated groups; a group that
+// returns nothing at all is retried one barcode at a time in case the filter
+// only honours single values. Products found before an error are returned
+// together with the error.
+func (s *productService) GetByBarcodes(ctx context.Context, barcodes []string) (map[string]Product, []string, error) {
+	var unique []string
+	seen := make(map[string]bool, len(barcodes))
+	for _, bc := range barcodes {
+		if bc != "" && !seen[bc] {
+			seen[bc] = true
+			unique = append(unique, bc)
+		}
+	}
+
+	var groups [][]string
+	for start := 0; start < len(unique); start += barcodeLookupGroupSize {
+		end := start + barcodeLookupGroupSize
+		if end > len(unique) {
+			end = len(unique)
+		}
+		groups = append(groups, unique[start:end])
+	}
+
+	var (
+		mu    sync.Mutex
+		found = make(map[string]Product, len(unique))
+		errs  []error
+		wg    sync.WaitGroup
+		sem   = make(chan struct{}, barcodeLookupConcurrency)
+	)
+	lookup := func(group []string) ([]Product, error) {
+		// A free slot and a cancelled context may be ready together; check
+		// the context first so that no request starts after cancellation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		defer func() { <-sem }()
+		products, err := s.findByBarcodes(ctx, group)
+		if err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+		return products, err
+	}
+
+	for _, group := range groups {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(group []string) {
+			defer wg.Done()
+			products, err := lookup(group)
+			if err == nil && len(products) == 0 && len(group) > 1 {
+				for _, bc := range group {
+					single, err := lookup([]string{bc})
+					if err != nil {
+						break
+					}
+					products = append(products, single...)
+				}
+			}
+			mu.Lock()
+			for _, p := range products {
+				found[p.Barcode] = p
+			}
+			mu.Unlock()
+		}(group)
+	}
+	wg.Wait()
+
+	var missing []string
+	for _, bc := range unique {
+		if _, ok := found[bc]; !ok {
+			missing = append(missing, bc)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return found, missing, err
+	}
+	return found, missing, errors.Join(errs...)
+}
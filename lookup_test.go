@@ -0,0 +1,97 @@
+package trendyol_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestGetByBarcodeNotFound bulunamayan ürünün tipli hata ile döndüğünü doğrular.
+func TestGetByBarcodeNotFound(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(fixtureProduct("LK-1"))
+	client := srv.Client()
+
+	p, err := client.Products.GetByBarcode(context.Background(), "LK-1")
+	if err != nil || p.Barcode != "LK-1" {
+		t.Fatalf("ürün bulunmalıydı: %v", err)
+	}
+
+	_, err = client.Products.GetByBarcode(context.Background(), "LK-404")
+	var nf *trendyol.ProductNotFoundError
+	if !errors.Is(err, trendyol.ErrNotFound) || !errors.As(err, &nf) || nf.Barcode != "LK-404" {
+		t.Fatalf("ProductNotFoundError bekleniyordu: %v", err)
+	}
+}
+
+// TestGetByBarcodes barkodların gruplanarak sorgulandığını, bulunanların ve
+// eksiklerin ayrı döndüğünü doğrular.
+func TestGetByBarcodes(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	var barcodes []string
+	for i := 0; i < 45; i++ {
+		bc := fmt.Sprintf("LK-%03d", i)
+		srv.AddProduct(fixtureProduct(bc))
+		barcodes = append(barcodes, bc)
+	}
+	barcodes = append(barcodes, "LK-X1", "LK-X2", "LK-000", "LK-X3")
+
+	found, missing, err := srv.Client().Products.GetByBarcodes(context.Background(), barcodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 45 || found["LK-044"].Barcode != "LK-044" {
+		t.Fatalf("45 ürün bulunmalıydı: %d", len(found))
+	}
+	sort.Strings(missing)
+	if fmt.Sprint(missing) != "[LK-X1 LK-X2 LK-X3]" {
+		t.Fatalf("beklenmeyen eksikler: %v", missing)
+	}
+	if n := len(srv.Calls(trendyol.EndpointGetProductsKey)); n != 3 {
+		t.Errorf("48 barkod 3 istekte sorgulanmalıydı: %d", n)
+	}
+}
+
+// TestGetByBarcodesCancel iptal edilen bağlamda sırada bekleyen grupların istek
+// göndermeden bırakıldığını doğrular.
+func TestGetByBarcodesCancel(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	var barcodes []string
+	for i := 0; i < 200; i++ {
+		barcodes = append(barcodes, fmt.Sprintf("LK-%03d", i))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	sent := 0
+	client := srv.Client(trendyol.WithMiddleware(func(next trendyol.RoundTripFunc) trendyol.RoundTripFunc {
+		return func(ctx context.Context, req *trendyol.Request) error {
+			mu.Lock()
+			sent++
+			mu.Unlock()
+			cancel()
+			<-ctx.Done()
+			return ctx.Err()
+		}
+	}))
+
+	_, missing, err := client.Products.GetByBarcodes(ctx, barcodes)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("context.Canceled bekleniyordu: %v", err)
+	}
+	if len(missing) != 200 {
+		t.Errorf("tüm barkodlar eksik dönmeliydi: %d", len(missing))
+	}
+	if sent > 4 {
+		t.Errorf("iptalden sonra istek gönderilmemeliydi: %d", sent)
+	}
+}
@@ -0,0 +1,44 @@
+package trendyol
+
+import (
+	"context"
+	"net/http"
+)
+
+// RoundTripFunc executes a single API request
+type RoundTripFunc func(ctx context.Context, req *Request) error
+
+// Middleware wraps a RoundTripFunc to observe or modify requests and responses.
+// Middlewares see the endpoint key, method, body and the decoded error of every
+// call made through Client.Do; rate limiting and retries run inside the chain.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware appends middlewares to the client. The first middleware
+// registered is the outermost one.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, mw...)
+	}
+}
+
+// chain wraps final with the given middlewares
+func chain(final RoundTripFunc, mws []Middleware) RoundTripFunc {
+	h := final
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// HeaderMiddleware sets static headers on every request
+func HeaderMiddleware(key, value string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *Request) error {
+			if req.Header == nil {
+				req.Header = http.Header{}
+			}
+			req.Header.Set(key, value)
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package trendyol_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestMiddlewareChain ara katmanların kayıt sırasıyla çalıştığını, uç nokta anahtarını
+// ve çözümlenmiş API hatasını gördüğünü ve başlık ekleyebildiğini doğrular.
+func TestMiddlewareChain(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+
+	var order []string
+	var seen []string
+	var lastErr error
+	record := func(name string) trendyol.Middleware {
+		return func(next trendyol.RoundTripFunc) trendyol.RoundTripFunc {
+			return func(ctx context.Context, req *trendyol.Request) error {
+				order = append(order, name)
+				return next(ctx, req)
+			}
+		}
+	}
+	observe := func(next trendyol.RoundTripFunc) trendyol.RoundTripFunc {
+		return func(ctx context.Context, req *trendyol.Request) error {
+			err := next(ctx, req)
+			seen = append(seen, req.Endpoint)
+			lastErr = err
+			return err
+		}
+	}
+
+	client := srv.Client(trendyol.WithMiddleware(record("outer"), record("inner"), observe),
+		trendyol.WithMiddleware(trendyol.HeaderMiddleware("X-Correlation-Id", "abc-123")))
+	ctx := context.Background()
+
+	if _, _, err := client.Products.List(ctx, 0, 10); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("beklenmeyen sıra: %v", order)
+	}
+	if len(seen) != 1 || seen[0] != trendyol.EndpointGetProductsKey {
+		t.Errorf("uç nokta anahtarı beklenmedik: %v", seen)
+	}
+	calls := srv.Calls(trendyol.EndpointGetProductsKey)
+	if len(calls) != 1 || calls[0].Header.Get("X-Correlation-Id") != "abc-123" {
+		t.Errorf("ek başlık sunucuya ulaşmadı")
+	}
+
+	_, err := client.Products.GetBatchStatus(ctx, "missing")
+	var apiErr *trendyol.Error
+	if err == nil || !errors.As(lastErr, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("ara katman çözümlenmiş hatayı görmeliydi: %v", lastErr)
+	}
+}
+
+// TestMiddlewareShortCircuit bir ara katmanın isteği sunucuya gitmeden yanıtlayabildiğini doğrular.
+func TestMiddlewareShortCircuit(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+
+	cached := func(next trendyol.RoundTripFunc) trendyol.RoundTripFunc {
+		return func(ctx context.Context, req *trendyol.Request) error {
+			if req.Endpoint == trendyol.EndpointGetShipmentProvidersKey {
+				if out, ok := req.Result.(*[]trendyol.ShipmentProvider); ok {
+					*out = []trendyol.ShipmentProvider{{ID: 42, Name: "Önbellek Kargo"}}
+					return nil
+				}
+			}
+			return next(ctx, req)
+		}
+	}
+	client := srv.Client(trendyol.WithMiddleware(cached))
+
+	providers, err := client.ShipmentProviders.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(providers) != 1 || providers[0].ID != 42 {
+		t.Errorf("önbellek yanıtı beklendi: %+v", providers)
+	}
+	if n := len(srv.Calls(trendyol.EndpointGetShipmentProvidersKey)); n != 0 {
+		t.Errorf("sunucuya istek gitmemeliydi, giden %d", n)
+	}
+}
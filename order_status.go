@@ -0,0 +1,103 @@
+package trendyol
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PackageStatus is the status of a shipment package
+type PackageStatus string
+
+func (s PackageStatus) String() string {
+	return string(s)
+}
+
+// packageTransitions lists the statuses a package may move to from each
+// status. Cargo-driven moves are included so that observed histories can be
+// checked too; sellers may only set Picking and Invoiced themselves.
+var packageTransitions = map[PackageStatus][]PackageStatus{
+	StatusAwaiting:  {StatusCreated, StatusCancelled},
+	StatusCreated:   {StatusPicking, StatusCancelled, StatusUnpacked, StatusUnSupplied},
+	StatusPicking:   {StatusInvoiced, StatusCancelled, StatusUnpacked, StatusUnSupplied},
+	StatusInvoiced:  {StatusShipped, StatusCancelled, StatusUnSupplied},
+	StatusShipped:   {StatusDelivered, StatusReturned},
+	StatusDelivered: {StatusReturned},
+}
+
+// Transitions returns the statuses the package may move to from s
+func (s PackageStatus) Transitions() []PackageStatus {
+	return append([]PackageStatus(nil), packageTransitions[s]...)
+}
+
+// CanTransitionTo reports whether a package in status s may move to next
+func (s PackageStatus) CanTransitionTo(next PackageStatus) bool {
+	for _, t := range packageTransitions[s] {
+		if t == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal reports whether no further transition is possible from s
+func (s PackageStatus) IsFinal() bool {
+	return len(packageTransitions[s]) == 0
+}
+
+// TransitionError is returned when a package status change is rejected locally
+type TransitionError struct {
+	PackageID int64
+	From      PackageStatus
+	To        PackageStatus
+}
+
+func (e *TransitionError) Error() string {
+	allowed := make([]string, 0, len(packageTransitions[e.From]))
+	for _, s := range packageTransitions[e.From] {
+		allowed = append(allowed, string(s))
+	}
+	if len(allowed) == 0 {
+		return fmt.Sprintf("package %d cannot move from %s to %s: %s is final", e.PackageID, e.From, e.To, e.From)
+	}
+	return fmt.Sprintf("package %d cannot move from %s to %s, allowed: %s", e.PackageID, e.From, e.To, strings.Join(allowed, ", "))
+}
+
+// statusUpdate builds an UpdatePackageStatusRequest for every active line of
+// the order after checking the transition
+func statusUpdate(order Order, to PackageStatus) (UpdatePackageStatusRequest, error) {
+	req := UpdatePackageStatusRequest{Status: to}
+	if !order.Status.CanTransitionTo(to) {
+		return req, &TransitionError{PackageID: order.ID, From: order.Status, To: to}
+	}
+	for _, l := range order.Lines {
+		if PackageStatus(l.OrderLineItemStatusName) == StatusCancelled {
+			continue
+		}
+		req.Lines = append(req.Lines, UpdatePackageStatusLine{LineID: l.ID, Quantity: l.Quantity})
+	}
+	if len(req.Lines) == 0 {
+		return req, fmt.Errorf("package %d has no lines to move to %s", order.ID, to)
+	}
+	return req, nil
+}
+
+func (s *orderService) MarkPicking(ctx context.Context, order Order) error {
+	req, err := statusUpdate(order, StatusPicking)
+	if err != nil {
+		return err
+	}
+	return s.UpdateStatus(ctx, order.ID, req)
+}
+
+func (s *orderService) MarkInvoiced(ctx context.Context, order Order, invoiceNumber string) error {
+	if invoiceNumber == "" {
+		return fmt.Errorf("invoice number is required to invoice package %d", order.ID)
+	}
+	req, err := statusUpdate(order, StatusInvoiced)
+	if err != nil {
+		return err
+	}
+	req.Params = map[string]string{"invoiceNumber": invoiceNumber}
+	return s.UpdateStatus(ctx, order.ID, req)
+}
@@ -0,0 +1,85 @@
+package trendyol_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestPackageStatusTransitions geçiş tablosunun izin verilen ve verilmeyen
+// adımları doğru ayırdığını doğrular.
+func TestPackageStatusTransitions(t *testing.T) {
+	cases := []struct {
+		from, to trendyol.PackageStatus
+		ok       bool
+	}{
+		{trendyol.StatusCreated, trendyol.StatusPicking, true},
+		{trendyol.StatusPicking, trendyol.StatusInvoiced, true},
+		{trendyol.StatusCreated, trendyol.StatusInvoiced, false},
+		{trendyol.StatusInvoiced, trendyol.StatusPicking, false},
+		{trendyol.StatusDelivered, trendyol.StatusReturned, true},
+		{trendyol.StatusCancelled, trendyol.StatusCreated, false},
+		{trendyol.StatusPicking, trendyol.StatusUnSupplied, true},
+		{trendyol.StatusShipped, trendyol.StatusUnSupplied, false},
+	}
+	for _, c := range cases {
+		if got := c.from.CanTransitionTo(c.to); got != c.ok {
+			t.Errorf("%s -> %s = %v, beklenen %v", c.from, c.to, got, c.ok)
+		}
+	}
+	if !trendyol.StatusCancelled.IsFinal() || !trendyol.StatusUnSupplied.IsFinal() || trendyol.StatusShipped.IsFinal() {
+		t.Error("son statüler hatalı belirlendi")
+	}
+}
+
+// TestMarkPickingAndInvoiced satırların siparişten türetildiğini ve geçersiz
+// geçişin istek gönderilmeden reddedildiğini doğrular.
+func TestMarkPickingAndInvoiced(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	order := srv.AddOrder(trendyol.Order{Lines: []trendyol.OrderLine{{Quantity: 2, Barcode: "S-1"}, {Quantity: 1, Barcode: "S-2"}}})
+
+	err := client.Orders.MarkInvoiced(ctx, order, "INV-1")
+	var terr *trendyol.TransitionError
+	if !errors.As(err, &terr) || terr.From != trendyol.StatusCreated || terr.To != trendyol.StatusInvoiced {
+		t.Fatalf("Created -> Invoiced reddedilmeliydi: %v", err)
+	}
+	if calls := srv.Calls(trendyol.EndpointUpdatePackageStatusKey); len(calls) != 0 {
+		t.Fatalf("geçersiz geçişte istek gönderilmemeliydi: %d", len(calls))
+	}
+
+	if err := client.Orders.MarkPicking(ctx, order); err != nil {
+		t.Fatal(err)
+	}
+	order, _ = srv.Order(order.ID)
+	if order.Status != trendyol.StatusPicking {
+		t.Fatalf("statü Picking olmalıydı: %s", order.Status)
+	}
+
+	if err := client.Orders.MarkInvoiced(ctx, order, "INV-1"); err != nil {
+		t.Fatal(err)
+	}
+	order, _ = srv.Order(order.ID)
+	if order.Status != trendyol.StatusInvoiced {
+		t.Fatalf("statü Invoiced olmalıydı: %s", order.Status)
+	}
+
+	var body trendyol.UpdatePackageStatusRequest
+	calls := srv.Calls(trendyol.EndpointUpdatePackageStatusKey)
+	if len(calls) != 2 {
+		t.Fatalf("iki istek beklendi: %d", len(calls))
+	}
+	if err := json.Unmarshal(calls[1].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Lines) != 2 || body.Lines[0].Quantity != 2 || body.Params["invoiceNumber"] != "INV-1" {
+		t.Fatalf("beklenmeyen gövde: %+v", body)
+	}
+}
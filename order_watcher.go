@@ -0,0 +1,336 @@
+package trendyol
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxOrderWindow is the longest startDate/endDate range getShipmentPackages accepts
+const maxOrderWindow = 14 * 24 * time.Hour
+
+// OrderEvent reports a shipment package that changed since the last poll
+type OrderEvent struct {
+	Order          Order
+	PreviousStatus PackageStatus    // empty when the package was not known before
+	Status         PackageStatus    // current package status
+	Transitions    []PackageHistory // status history entries added since the previous observation
+}
+
+// StatusChanged reports whether the package moved to a different status
+func (e OrderEvent) StatusChanged() bool {
+	return e.PreviousStatus != e.Status
+}
+
+// PackageState is the last observed state of a shipment package
+type PackageState struct {
+	Status           PackageStatus `json:"status"`
+	LastModifiedDate int64         `json:"lastModifiedDate"`
+}
+
+// Checkpoint is the position of an OrderWatcher
+type Checkpoint struct {
+	Since    time.Time              `json:"since"`    // end of the last fully processed window
+	Packages map[int64]PackageState `json:"packages"` // packages seen within the overlap period
+}
+
+// CheckpointStore persists the watcher checkpoint between polls and restarts
+type CheckpointStore interface {
+	Load(ctx context.Context) (Checkpoint, error)
+	Save(ctx context.Context, cp Checkpoint) error
+}
+
+// memoryCheckpointStore keeps the checkpoint in memory
+type memoryCheckpointStore struct {
+	mu sync.Mutex
+	cp Checkpoint
+}
+
+// NewMemoryCheckpointStore returns a CheckpointStore that lives as long as the process
+func NewMemoryCheckpointStore() CheckpointStore {
+	return &memoryCheckpointStore{}
+}
+
+func (s *memoryCheckpointStore) Load(ctx context.Context) (Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyCheckpoint(s.cp), nil
+}
+
+func (s *memoryCheckpointStore) Save(ctx context.Context, cp Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cp = copyCheckpoint(cp)
+	return nil
+}
+
+func copyCheckpoint(cp Checkpoint) Checkpoint {
+	out := Checkpoint{Since: cp.Since, Packages: make(map[int64]PackageState, len(cp.Packages))}
+	for k, v := range cp.Packages {
+		out.Packages[k] = v
+	}
+	return out
+}
+
+// fileCheckpointStore keeps the checkpoint in a JSON file
+type fileCheckpointStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCheckpointStore returns a CheckpointStore backed by a JSON file. A
+// missing file is treated as an empty checkpoint.
+func NewFileCheckpointStore(path string) CheckpointStore {
+	return &fileCheckpointStore{path: path}
+}
+
+func (s *fileCheckpointStore) Load(ctx context.Context) (Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var cp Checkpoint
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return cp, err
+	}
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return cp, fmt.Errorf("failed to decode checkpoint %s: %w", s.path, err)
+	}
+	return cp, nil
+}
+
+func (s *fileCheckpointStore) Save(ctx context.Context, cp Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeFileAtomic(s.path, cp)
+}
+
+// writeFileAtomic writes v as JSON through a temporary file and rename
+func writeFileAtomic(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// WatcherOption configures an OrderWatcher
+type WatcherOption func(*OrderWatcher)
+
+// WithCheckpointStore sets where the watcher position is persisted
+func WithCheckpointStore(store CheckpointStore) WatcherOption {
+	return func(w *OrderWatcher) {
+		w.store = store
+	}
+}
+
+// WithPollInterval sets the delay between polls, default 1 minute
+func WithPollInterval(d time.Duration) WatcherOption {
+	return func(w *OrderWatcher) {
+		w.interval = d
+	}
+}
+
+// WithPollOverlap sets how far each window reaches back before the previous
+// one to catch late modification dates, default 5 minutes
+func WithPollOverlap(d time.Duration) WatcherOption {
+	return func(w *OrderWatcher) {
+		w.overlap = d
+	}
+}
+
+// WithInitialLookback sets the start of the first window when no checkpoint
+// exists, default 24 hours
+func WithInitialLookback(d time.Duration) WatcherOption {
+	return func(w *OrderWatcher) {
+		w.lookback = d
+	}
+}
+
+// WithWatcherClock sets the time source, mainly for tests
+func WithWatcherClock(now func() time.Time) WatcherOption {
+	return func(w *OrderWatcher) {
+		w.now = now
+	}
+}
+
+// WithWatcherErrorHandler is called when a poll in Watch fails; the watcher
+// keeps polling from the last saved checkpoint
+func WithWatcherErrorHandler(fn func(error)) WatcherOption {
+	return func(w *OrderWatcher) {
+		w.onError = fn
+	}
+}
+
+// OrderWatcher polls Orders.List with a sliding window on the package
+// modification date and reports changed packages. It is an alternative to
+// webhooks for deployments without a public URL.
+type OrderWatcher struct {
+	orders   OrderService
+	store    CheckpointStore
+	interval time.Duration
+	overlap  time.Duration
+	lookback time.Duration
+	pageSize int
+	now      func() time.Time
+	onError  func(error)
+}
+
+// NewOrderWatcher creates a watcher on top of orders, usually client.Orders
+func NewOrderWatcher(orders OrderService, opts ...WatcherOption) *OrderWatcher {
+	w := &OrderWatcher{
+		orders:   orders,
+		store:    NewMemoryCheckpointStore(),
+		interval: time.Minute,
+		overlap:  5 * time.Minute,
+		lookback: 24 * time.Hour,
+		pageSize: 200,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Watch polls until ctx is cancelled and sends every change on the returned
+// channel, which is closed when the watcher stops. The checkpoint is saved
+// after the events of a poll were delivered.
+func (w *OrderWatcher) Watch(ctx context.Context) <-chan OrderEvent {
+	events := make(chan OrderEvent)
+	go func() {
+		defer close(events)
+		for {
+			err := w.poll(ctx, func(e OrderEvent) error {
+				select {
+				case events <- e:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			if err != nil && ctx.Err() == nil && w.onError != nil {
+				w.onError(err)
+			}
+
+			timer := time.NewTimer(w.interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+	return events
+}
+
+// Poll runs a single poll and returns the changes found. The checkpoint is
+// saved before Poll returns.
+func (w *OrderWatcher) Poll(ctx context.Context) ([]OrderEvent, error) {
+	var events []OrderEvent
+	err := w.poll(ctx, func(e OrderEvent) error {
+		events = append(events, e)
+		return nil
+	})
+	return events, err
+}
+
+func (w *OrderWatcher) poll(ctx context.Context, emit func(OrderEvent) error) error {
+	cp, err := w.store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	if cp.Packages == nil {
+		cp.Packages = map[int64]PackageState{}
+	}
+
+	now := w.now()
+	since := cp.Since
+	if since.IsZero() {
+		since = now.Add(-w.lookback)
+	}
+
+	for start := since.Add(-w.overlap); start.Before(now); start = start.Add(maxOrderWindow) {
+		end := start.Add(maxOrderWindow)
+		if end.After(now) {
+			end = now
+		}
+		opts := ListOrdersOptions{
+			StartDate:        &start,
+			EndDate:          &end,
+			OrderByField:     "PackageLastModifiedDate",
+			OrderByDirection: "ASC",
+			Size:             w.pageSize,
+		}
+		err := w.orders.ForEach(ctx, opts, func(o Order) error {
+			prev, known := cp.Packages[o.ID]
+			if known && prev.LastModifiedDate >= o.LastModifiedDate {
+				return nil
+			}
+			if err := emit(newOrderEvent(o, prev, known, since)); err != nil {
+				return err
+			}
+			cp.Packages[o.ID] = PackageState{Status: o.Status, LastModifiedDate: o.LastModifiedDate}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cp.Since = now
+	// Packages older than the next window no longer need deduplication
+	horizon := now.Add(-w.overlap).UnixMilli()
+	for id, st := range cp.Packages {
+		if st.LastModifiedDate < horizon {
+			delete(cp.Packages, id)
+		}
+	}
+	if err := w.store.Save(ctx, cp); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// newOrderEvent derives the transitions of o from its package histories
+func newOrderEvent(o Order, prev PackageState, known bool, since time.Time) OrderEvent {
+	histories := append([]PackageHistory(nil), o.PackageHistories...)
+	sort.SliceStable(histories, func(i, j int) bool { return histories[i].CreatedDate < histories[j].CreatedDate })
+
+	threshold := since.UnixMilli()
+	e := OrderEvent{Order: o, Status: o.Status}
+	if known {
+		threshold = prev.LastModifiedDate
+		e.PreviousStatus = prev.Status
+	}
+	for _, h := range histories {
+		if h.CreatedDate > threshold {
+			e.Transitions = append(e.Transitions, h)
+		} else if !known {
+			e.PreviousStatus = h.Status
+		}
+	}
+	return e
+}
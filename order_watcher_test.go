@@ -0,0 +1,129 @@
+package trendyol_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// testClock is a manually advanced time source shared by the fake and the watcher
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// TestOrderWatcherPoll yeni paketlerin, statü geçişlerinin bildirildiğini ve
+// kaydedilen konumdan devam eden izleyicinin aynı değişikliği tekrar
+// bildirmediğini doğrular.
+func TestOrderWatcherPoll(t *testing.T) {
+	clock := &testClock{now: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)}
+	srv := trendyoltest.NewServer(trendyoltest.WithClock(clock.Now))
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	store := trendyol.NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	newWatcher := func() *trendyol.OrderWatcher {
+		return trendyol.NewOrderWatcher(client.Orders,
+			trendyol.WithCheckpointStore(store),
+			trendyol.WithWatcherClock(clock.Now),
+		)
+	}
+	w := newWatcher()
+
+	order := srv.AddOrder(trendyol.Order{Lines: []trendyol.OrderLine{{Quantity: 1, Barcode: "W-1"}}})
+	clock.Advance(time.Minute)
+
+	events, err := w.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Order.ID != order.ID || events[0].Status != trendyol.StatusCreated || events[0].PreviousStatus != "" {
+		t.Fatalf("yeni paket bildirilmeliydi: %+v", events)
+	}
+
+	events, err = w.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("değişmeyen paket tekrar bildirilmemeliydi: %+v", events)
+	}
+
+	clock.Advance(time.Minute)
+	err = client.Orders.UpdateStatus(ctx, order.ID, trendyol.UpdatePackageStatusRequest{
+		Status: trendyol.StatusPicking,
+		Lines:  []trendyol.UpdatePackageStatusLine{{LineID: order.Lines[0].ID, Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+
+	events, err = w.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || !events[0].StatusChanged() {
+		t.Fatalf("statü geçişi bildirilmeliydi: %+v", events)
+	}
+	e := events[0]
+	if e.PreviousStatus != trendyol.StatusCreated || e.Status != trendyol.StatusPicking {
+		t.Fatalf("geçiş %s -> %s olmamalıydı", e.PreviousStatus, e.Status)
+	}
+	if len(e.Transitions) != 1 || e.Transitions[0].Status != trendyol.StatusPicking {
+		t.Fatalf("yalnızca yeni geçmiş kaydı dönmeliydi: %+v", e.Transitions)
+	}
+
+	clock.Advance(time.Minute)
+	events, err = newWatcher().Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("yeniden başlatılan izleyici aynı değişikliği bildirmemeliydi: %+v", events)
+	}
+}
+
+// TestOrderWatcherWatch değişikliklerin kanala gönderildiğini ve bağlam iptal
+// edildiğinde kanalın kapandığını doğrular.
+func TestOrderWatcherWatch(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	order := srv.AddOrder(trendyol.Order{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := trendyol.NewOrderWatcher(srv.Client().Orders, trendyol.WithPollInterval(time.Millisecond))
+	events := w.Watch(ctx)
+
+	select {
+	case e := <-events:
+		if e.Order.ID != order.ID {
+			t.Fatalf("beklenmeyen paket: %d", e.Order.ID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("olay gelmedi")
+	}
+
+	cancel()
+	for range events {
+	}
+}
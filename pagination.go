@@ -0,0 +1,102 @@
+package trendyol
+
+import (
+	"context"
+	"time"
+)
+
+// defaultPageSize is used by the ForEach helpers when no page size is given
+const defaultPageSize = 50
+
+// pageFetcher fetches a single page of a paginated endpoint
+type pageFetcher[T any] func(ctx context.Context, page int) ([]T, *PaginatedResponse, error)
+
+// walkPages fetches pages starting at startPage and calls fn for every item
+// until the last page is reached, fn returns an error or ctx is cancelled.
+// Endpoints that do not report TotalPages (TotalPages < 0) are walked until
+// an empty page is returned. Each page goes through Client.Do, so the client's
+// rate limiter and retry policy apply per page.
+func walkPages[T any](ctx context.Context, startPage int, fetch pageFetcher[T], fn func(T) error) error {
+	for page := startPage; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		items, meta, err := fetch(ctx, page)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		if meta != nil && meta.TotalPages >= 0 && page+1 >= meta.TotalPages {
+			return nil
+		}
+	}
+}
+
+// ForEach walks every page of ListWithOptions, see ProductService.ForEach
+func (s *productService) ForEach(ctx context.Context, size int, opts *ProductListOptions, fn func(Product) error) error {
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	return walkPages(ctx, 0, func(ctx context.Context, page int) ([]Product, *PaginatedResponse, error) {
+		return s.ListWithOptions(ctx, page, size, opts)
+	}, fn)
+}
+
+// ForEach walks the pages of List starting at opts.Page
+func (s *orderService) ForEach(ctx context.Context, opts ListOrdersOptions, fn func(Order) error) error {
+	if opts.Size <= 0 {
+		opts.Size = defaultPageSize
+	}
+	return walkPages(ctx, opts.Page, func(ctx context.Context, page int) ([]Order, *PaginatedResponse, error) {
+		o := opts
+		o.Page = page
+		return s.List(ctx, o)
+	}, fn)
+}
+
+// ForEach walks every claim with the given item status
+func (s *claimService) ForEach(ctx context.Context, status string, size int, fn func(Claim) error) error {
+	return s.ForEachWithOptions(ctx, ListClaimsOptions{ClaimItemStatus: status, Size: size}, fn)
+}
+
+// ForEachWithOptions walks the pages of ListWithOptions starting at opts.Page
+func (s *claimService) ForEachWithOptions(ctx context.Context, opts ListClaimsOptions, fn func(Claim) error) error {
+	if opts.Size <= 0 {
+		opts.Size = defaultPageSize
+	}
+	return walkPages(ctx, opts.Page, func(ctx context.Context, page int) ([]Claim, *PaginatedResponse, error) {
+		o := opts
+		o.Page = page
+		return s.ListWithOptions(ctx, o)
+	}, fn)
+}
+
+// ForEachSettlement walks every page of a single GetSettlements range
+func (s *financeService) ForEachSettlement(ctx context.Context, startDate, endDate time.Time, size int, fn func(Settlement) error) error {
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	return walkPages(ctx, 0, func(ctx context.Context, page int) ([]Settlement, *PaginatedResponse, error) {
+		return s.GetSettlements(ctx, startDate, endDate, page, size)
+	}, fn)
+}
+
+// ForEachBrand walks ListBrands until an empty page is returned
+func (s *categoryService) ForEachBrand(ctx context.Context, size int, fn func(Brand) error) error {
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	return walkPages(ctx, 0, func(ctx context.Context, page int) ([]Brand, *PaginatedResponse, error) {
+		return s.ListBrands(ctx, page, size)
+	}, fn)
+}
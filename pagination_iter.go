@@ -0,0 +1,95 @@
+//go:build go1.23
+
+package trendyol
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"time"
+)
+
+// errStopIteration ends a ForEach walk when the range loop body breaks
+var errStopIteration = errors.New("trendyol: iteration stopped")
+
+// seq adapts a ForEach style walk into a range-over-func iterator. A walk
+// error, including context cancellation, is yielded once as the final pair.
+func seq[T any](walk func(fn func(T) error) error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := walk(func(item T) error {
+			if !yield(item, nil) {
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopIteration) {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// AllProducts iterates over every product matching opts across all pages
+//
+//	for p, err := range client.AllProducts(ctx, 100, nil) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(p.Barcode)
+//	}
+func (c *Client) AllProducts(ctx context.Context, size int, opts *ProductListOptions) iter.Seq2[Product, error] {
+	return seq(func(fn func(Product) error) error {
+		return c.Products.ForEach(ctx, size, opts, fn)
+	})
+}
+
+// AllOrders iterates over every order matching opts, starting at opts.Page
+func (c *Client) AllOrders(ctx context.Context, opts ListOrdersOptions) iter.Seq2[Order, error] {
+	return seq(func(fn func(Order) error) error {
+		return c.Orders.ForEach(ctx, opts, fn)
+	})
+}
+
+// AllClaims iterates over every claim with the given item status
+func (c *Client) AllClaims(ctx context.Context, status string, size int) iter.Seq2[Claim, error] {
+	return seq(func(fn func(Claim) error) error {
+		return c.Claims.ForEach(ctx, status, size, fn)
+	})
+}
+
+// AllClaimsWithOptions iterates over every claim matching opts, starting at opts.Page
+func (c *Client) AllClaimsWithOptions(ctx context.Context, opts ListClaimsOptions) iter.Seq2[Claim, error] {
+	return seq(func(fn func(Claim) error) error {
+		return c.Claims.ForEachWithOptions(ctx, opts, fn)
+	})
+}
+
+// AllSettlements iterates over every settlement between startDate and endDate
+func (c *Client) AllSettlements(ctx context.Context, startDate, endDate time.Time, size int) iter.Seq2[Settlement, error] {
+	return seq(func(fn func(Settlement) error) error {
+		return c.Finance.ForEachSettlement(ctx, startDate, endDate, size, fn)
+	})
+}
+
+// AllSettlementsInRange iterates over every settlement matching q, splitting
+// long ranges into windows the endpoint accepts
+func (c *Client) AllSettlementsInRange(ctx context.Context, q SettlementQuery) iter.Seq2[Settlement, error] {
+	return seq(func(fn func(Settlement) error) error {
+		return c.Finance.ForEachSettlementInRange(ctx, q, fn)
+	})
+}
+
+// AllOtherFinancials iterates over every other financial transaction matching q
+func (c *Client) AllOtherFinancials(ctx context.Context, q OtherFinancialQuery) iter.Seq2[OtherFinancial, error] {
+	return seq(func(fn func(OtherFinancial) error) error {
+		return c.Finance.ForEachOtherFinancial(ctx, q, fn)
+	})
+}
+
+// AllBrands iterates over every brand. The brands endpoint does not report
+// page counts, so iteration ends at the first empty page.
+func (c *Client) AllBrands(ctx context.Context, size int) iter.Seq2[Brand, error] {
+	return seq(func(fn func(Brand) error) error {
+		return c.Categories.ForEachBrand(ctx, size, fn)
+	})
+}
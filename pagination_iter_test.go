@@ -0,0 +1,48 @@
+//go:build go1.23
+
+package trendyol_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestAllProductsIterator range-over-func iteratörünün tüm sayfaları gezdiğini ve
+// döngüden çıkıldığında yeni sayfa istemediğini doğrular.
+func TestAllProductsIterator(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 5; i++ {
+		srv.AddProduct(trendyol.Product{Barcode: fmt.Sprintf("BC-%d", i), Title: "Ürün"})
+	}
+	client := srv.Client()
+	ctx := context.Background()
+
+	count := 0
+	for _, err := range client.AllProducts(ctx, 2, nil) {
+		if err != nil {
+			t.Fatalf("AllProducts: %v", err)
+		}
+		count++
+	}
+	if count != 5 {
+		t.Fatalf("5 ürün beklendi, gelen %d", count)
+	}
+
+	before := len(srv.Calls(trendyol.EndpointGetProductsKey))
+	for p, err := range client.AllProducts(ctx, 2, nil) {
+		if err != nil {
+			t.Fatalf("AllProducts: %v", err)
+		}
+		if p.Barcode == "BC-0" {
+			break
+		}
+	}
+	if n := len(srv.Calls(trendyol.EndpointGetProductsKey)) - before; n != 1 {
+		t.Errorf("break sonrası 1 istek beklendi, gelen %d", n)
+	}
+}
@@ -0,0 +1,90 @@
+package trendyol_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestProductsForEach tüm sayfaların gezildiğini ve son sayfada durulduğunu doğrular.
+func TestProductsForEach(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 7; i++ {
+		srv.AddProduct(trendyol.Product{Barcode: fmt.Sprintf("BC-%d", i), Title: "Ürün"})
+	}
+	client := srv.Client()
+
+	var barcodes []string
+	err := client.Products.ForEach(context.Background(), 3, nil, func(p trendyol.Product) error {
+		barcodes = append(barcodes, p.Barcode)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEach: %v", err)
+	}
+	if len(barcodes) != 7 {
+		t.Fatalf("7 ürün beklendi, gelen %d", len(barcodes))
+	}
+	if n := len(srv.Calls(trendyol.EndpointGetProductsKey)); n != 3 {
+		t.Errorf("3 sayfa isteği beklendi, gelen %d", n)
+	}
+}
+
+// TestBrandsForEachStopsOnEmptyPage TotalPages=-1 dönen marka uç noktasında boş sayfada durulduğunu doğrular.
+func TestBrandsForEachStopsOnEmptyPage(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	count := 0
+	err := client.Categories.ForEachBrand(context.Background(), 3, func(trendyol.Brand) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachBrand: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("4 marka beklendi, gelen %d", count)
+	}
+	if n := len(srv.Calls(trendyol.EndpointGetBrandsKey)); n != 3 {
+		t.Errorf("boş sayfa dahil 3 istek beklendi, gelen %d", n)
+	}
+}
+
+// TestForEachStopsOnCallbackErrorAndCancel geri çağrı hatası ve context iptalinde durulduğunu doğrular.
+func TestForEachStopsOnCallbackErrorAndCancel(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 5; i++ {
+		srv.AddOrder(trendyol.Order{})
+	}
+	client := srv.Client()
+
+	stop := errors.New("stop")
+	err := client.Orders.ForEach(context.Background(), trendyol.ListOrdersOptions{Size: 2}, func(trendyol.Order) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("geri çağrı hatası dönmeliydi, gelen %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	seen := 0
+	err = client.Orders.ForEach(ctx, trendyol.ListOrdersOptions{Size: 2}, func(trendyol.Order) error {
+		seen++
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("context.Canceled beklendi, gelen %v", err)
+	}
+	if seen != 2 {
+		t.Errorf("iptal sonrası yeni sayfa çekilmemeliydi, görülen %d", seen)
+	}
+}
@@ -0,0 +1,477 @@
+package trendyol
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ProductCSVColumns is the column schema written by WriteProductsCSV. Readers
+// match columns by header name, so the order of an edited file may differ.
+var ProductCSVColumns = []string{
+	"barcode", "title", "productMainId", "brandId", "categoryId", "quantity", "stockCode",
+	"dimensionalWeight", "description", "currencyType", "listPrice", "salePrice", "vatRate",
+	"cargoCompanyId", "shipmentAddressId", "returningAddressId", "deliveryDuration",
+	"fastDeliveryType", "images", "attributes",
+}
+
+// Separators used inside the images and attributes cells. A backslash
+// escapes them, itself and csvValueIDPrefix inside names, values and URLs.
+const (
+	csvImageSeparator     = "|"
+	csvAttributeSeparator = ";"
+	// csvValueIDPrefix marks an attribute value written as its numeric ID,
+	// so that a custom value such as "42" is not read back as an ID
+	csvValueIDPrefix = "#"
+)
+
+var csvEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	csvImageSeparator, `\`+csvImageSeparator,
+	csvAttributeSeparator, `\`+csvAttributeSeparator,
+	"=", `\=`,
+	csvValueIDPrefix, `\`+csvValueIDPrefix,
+)
+
+const utf8BOM = "\ufeff"
+
+// CSVOptions configures product CSV encoding and decoding
+type CSVOptions struct {
+	// Comma is the field delimiter, default ','. Excel with a Turkish locale
+	// expects ';'.
+	Comma rune
+	// BOM writes a UTF-8 byte order mark so that Excel detects the encoding.
+	// A mark is always skipped when reading.
+	BOM bool
+	// Categories resolves attribute and value names. Without it attributes
+	// are written and read as numeric IDs.
+	Categories CategoryService
+}
+
+// CSVRowError describes a row that could not be decoded
+type CSVRowError struct {
+	Row     int // 1-based line number including the header
+	Barcode string
+	Column  string
+	Err     error
+}
+
+func (e CSVRowError) Error() string {
+	return fmt.Sprintf("row %d (%s), column %s: %v", e.Row, e.Barcode, e.Column, e.Err)
+}
+
+func (e CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+// attributeDefs caches category attributes for one encode or decode run
+type attributeDefs struct {
+	categories CategoryService
+	cache      map[int][]CategoryAttribute
+	failed     map[int]error // categories whose lookup failed, not retried
+}
+
+func (d *attributeDefs) get(ctx context.Context, categoryID int) ([]CategoryAttribute, error) {
+	if d.categories == nil || categoryID == 0 {
+		return nil, nil
+	}
+	if attrs, ok := d.cache[categoryID]; ok {
+		return attrs, nil
+	}
+	if err, ok := d.failed[categoryID]; ok {
+		return nil, err
+	}
+	attrs, err := d.categories.GetCategoryAttributes(ctx, categoryID)
+	if err != nil {
+		err = fmt.Errorf("failed to fetch attributes of category %d: %w", categoryID, err)
+		if ctx.Err() == nil {
+			if d.failed == nil {
+				d.failed = map[int]error{}
+			}
+			d.failed[categoryID] = err
+		}
+		return nil, err
+	}
+	d.cache[categoryID] = attrs
+	return attrs, nil
+}
+
+// WriteProductsCSV writes products with the ProductCSVColumns schema.
+// Attributes are written as "Name=Value" pairs separated by ';' and images as
+// URLs separated by '|'.
+func WriteProductsCSV(ctx context.Context, w io.Writer, products []Product, opts *CSVOptions) error {
+	var o CSVOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.BOM {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return err
+		}
+	}
+	cw := csv.NewWriter(w)
+	if o.Comma != 0 {
+		cw.Comma = o.Comma
+	}
+	if err := cw.Write(ProductCSVColumns); err != nil {
+		return err
+	}
+
+	defs := &attributeDefs{categories: o.Categories, cache: map[int][]CategoryAttribute{}}
+	for _, p := range products {
+		attrs, err := defs.get(ctx, p.CategoryID)
+		if err != nil {
+			return err
+		}
+		images := make([]string, len(p.Images))
+		for i, img := range p.Images {
+			images[i] = csvEscaper.Replace(img.URL)
+		}
+		var deliveryDuration, fastDelivery string
+		if p.DeliveryOption != nil {
+			deliveryDuration = formatInt(p.DeliveryOption.DeliveryDuration)
+			fastDelivery = p.DeliveryOption.FastDeliveryType
+		}
+		row := []string{
+			p.Barcode, p.Title, p.ProductMainID, formatInt(p.BrandID), formatInt(p.CategoryID),
+			strconv.Itoa(p.Quantity), p.StockCode, formatFloat(p.DimensionalWeight), p.Description,
+			p.CurrencyType, formatFloat(p.ListPrice), formatFloat(p.SalePrice), strconv.Itoa(p.VATRate),
+			formatInt(p.CargoCompanyID), formatInt(p.ShipmentAddressID), formatInt(p.ReturningAddressID),
+			deliveryDuration, fastDelivery,
+			strings.Join(images, csvImageSeparator),
+			formatAttributes(p.Attributes, attrs),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadProductsCSV decodes products written by WriteProductsCSV or edited in a
+// spreadsheet. Rows that fail to decode, including rows whose category
+// attributes cannot be fetched, are skipped and reported as row errors; the
+// error is only set for unreadable input, a missing barcode column or a
+// cancelled ctx. The products can be passed to Products.Create or
+// Products.Update as they are.
+func ReadProductsCSV(ctx context.Context, r io.Reader, opts *CSVOptions) ([]Product, []CSVRowError, error) {
+	var o CSVOptions
+	if opts != nil {
+		o = *opts
+	}
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(len(utf8BOM)); err == nil && string(b) == utf8BOM {
+		br.Discard(len(utf8BOM))
+	}
+	cr := csv.NewReader(br)
+	if o.Comma != 0 {
+		cr.Comma = o.Comma
+	}
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := index["barcode"]; !ok {
+		return nil, nil, errors.New("csv header has no barcode column")
+	}
+
+	defs := &attributeDefs{categories: o.Categories, cache: map[int][]CategoryAttribute{}}
+	var products []Product
+	var rowErrs []CSVRowError
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			rowErrs = append(rowErrs, CSVRowError{Row: line, Err: perr.Err})
+			continue
+		}
+		if err != nil {
+			return products, rowErrs, err
+		}
+
+		cell := func(col string) string {
+			if i, ok := index[strings.ToLower(col)]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		p, col, err := decodeProductRow(ctx, cell, defs)
+		if err != nil {
+			if col == "" {
+				return products, rowErrs, err
+			}
+			rowErrs = append(rowErrs, CSVRowError{Row: line, Barcode: cell("barcode"), Column: col, Err: err})
+			continue
+		}
+		products = append(products, p)
+	}
+	return products, rowErrs, nil
+}
+
+// decodeProductRow builds a product from one row. A returned column names the
+// offending cell; an error without a column, only returned once ctx is done,
+// aborts the whole read.
+func decodeProductRow(ctx context.Context, cell func(string) string, defs *attributeDefs) (Product, string, error) {
+	p := Product{
+		Barcode:       cell("barcode"),
+		Title:         cell("title"),
+		ProductMainID: cell("productMainId"),
+		StockCode:     cell("stockCode"),
+		Description:   cell("description"),
+		CurrencyType:  cell("currencyType"),
+	}
+	if p.Barcode == "" {
+		return p, "barcode", errors.New("barcode is empty")
+	}
+
+	ints := []struct {
+		col string
+		dst *int
+	}{
+		{"brandId", &p.BrandID}, {"categoryId", &p.CategoryID}, {"quantity", &p.Quantity},
+		{"vatRate", &p.VATRate}, {"cargoCompanyId", &p.CargoCompanyID},
+		{"shipmentAddressId", &p.ShipmentAddressID}, {"returningAddressId", &p.ReturningAddressID},
+	}
+	for _, f := range ints {
+		v, err := parseCSVInt(cell(f.col))
+		if err != nil {
+			return p, f.col, err
+		}
+		*f.dst = v
+	}
+	floats := []struct {
+		col string
+		dst *float64
+	}{
+		{"dimensionalWeight", &p.DimensionalWeight}, {"listPrice", &p.ListPrice}, {"salePrice", &p.SalePrice},
+	}
+	for _, f := range floats {
+		v, err := parseCSVFloat(cell(f.col))
+		if err != nil {
+			return p, f.col, err
+		}
+		*f.dst = v
+	}
+
+	if d, fast := cell("deliveryDuration"), cell("fastDeliveryType"); d != "" || fast != "" {
+		duration, err := parseCSVInt(d)
+		if err != nil {
+			return p, "deliveryDuration", err
+		}
+		p.DeliveryOption = &DeliveryOption{DeliveryDuration: duration, FastDeliveryType: fast}
+	}
+
+	for _, url := range splitEscaped(cell("images"), csvImageSeparator[0]) {
+		if url = strings.TrimSpace(url); url != "" {
+			p.Images = append(p.Images, ProductImage{URL: unescapeCSV(url)})
+		}
+	}
+
+	attrs, err := defs.get(ctx, p.CategoryID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return p, "", err
+		}
+		return p, "categoryId", err
+	}
+	p.Attributes, err = parseAttributes(cell("attributes"), attrs)
+	if err != nil {
+		return p, "attributes", err
+	}
+	return p, "", nil
+}
+
+// formatAttributes renders attributes as "Name=Value" pairs, using the
+// category definitions when available and numeric IDs otherwise. Value IDs
+// without a definition are written as "#42".
+func formatAttributes(attrs []ProductAttribute, defs []CategoryAttribute) string {
+	parts := make([]string, 0, len(attrs))
+	for _, a := range attrs {
+		name := strconv.Itoa(a.AttributeID)
+		var value string
+		switch {
+		case a.CustomAttributeValue != "":
+			value = csvEscaper.Replace(a.CustomAttributeValue)
+		case a.AttributeValueID != 0:
+			value = csvValueIDPrefix + strconv.Itoa(a.AttributeValueID)
+		default:
+			value = csvEscaper.Replace(a.AttributeValue)
+		}
+		if def, ok := findAttribute(defs, name); ok {
+			name = csvEscaper.Replace(def.AttributeName)
+			for _, v := range def.AttributeValues {
+				if a.AttributeValueID != 0 && v.AttributeValueID == a.AttributeValueID {
+					value = csvEscaper.Replace(v.Value)
+				}
+			}
+		}
+		parts = append(parts, name+"="+value)
+	}
+	return strings.Join(parts, csvAttributeSeparator+" ")
+}
+
+// parseAttributes resolves "Name=Value" pairs against the category
+// definitions. Without definitions names must be numeric IDs. Values written
+// as "#42" are value IDs, anything else is a value name or a custom value.
+func parseAttributes(s string, defs []CategoryAttribute) ([]ProductAttribute, error) {
+	var out []ProductAttribute
+	for _, part := range splitEscaped(s, csvAttributeSeparator[0]) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, rawValue, ok := cutEscaped(part, '=')
+		key, rawValue = unescapeCSV(strings.TrimSpace(key)), strings.TrimSpace(rawValue)
+		if !ok || key == "" || rawValue == "" {
+			return nil, fmt.Errorf("attribute %q is not in Name=Value form", part)
+		}
+		value := unescapeCSV(rawValue)
+		valueID := 0
+		if strings.HasPrefix(rawValue, csvValueIDPrefix) {
+			id, err := strconv.Atoi(rawValue[len(csvValueIDPrefix):])
+			if err != nil || id <= 0 {
+				return nil, fmt.Errorf("attribute value ID %q is not a positive integer", rawValue)
+			}
+			valueID = id
+		}
+
+		if defs == nil {
+			id, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %q must be a numeric ID when no category attributes are available", key)
+			}
+			a := ProductAttribute{AttributeID: id, AttributeValueID: valueID}
+			if valueID == 0 {
+				a.CustomAttributeValue = value
+			}
+			out = append(out, a)
+			continue
+		}
+
+		def, found := findAttribute(defs, key)
+		if !found {
+			return nil, fmt.Errorf("unknown attribute %q", key)
+		}
+		a := ProductAttribute{AttributeID: def.AttributeID}
+		for _, v := range def.AttributeValues {
+			if (valueID != 0 && v.AttributeValueID == valueID) || (valueID == 0 && strings.EqualFold(v.Value, value)) {
+				a.AttributeValueID = v.AttributeValueID
+				break
+			}
+		}
+		if valueID != 0 && a.AttributeValueID == 0 {
+			return nil, fmt.Errorf("value ID %d is not defined for attribute %s", valueID, def.AttributeName)
+		}
+		if a.AttributeValueID == 0 {
+			if !def.AllowCustomValue && len(def.AttributeValues) > 0 {
+				return nil, fmt.Errorf("value %q is not allowed for attribute %s", value, def.AttributeName)
+			}
+			a.CustomAttributeValue = value
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+// splitEscaped splits s at every sep not escaped with a backslash, leaving
+// the escapes in the parts
+func splitEscaped(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// cutEscaped is strings.Cut for the first sep not escaped with a backslash
+func cutEscaped(s string, sep byte) (before, after string, found bool) {
+	parts := splitEscaped(s, sep)
+	if len(parts) == 1 {
+		return s, "", false
+	}
+	return parts[0], s[len(parts[0])+1:], true
+}
+
+// unescapeCSV removes the backslash escapes written by csvEscaper
+func unescapeCSV(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func findAttribute(defs []CategoryAttribute, key string) (CategoryAttribute, bool) {
+	for _, def := range defs {
+		if strings.EqualFold(def.AttributeName, key) || strconv.Itoa(def.AttributeID) == key {
+			return def, true
+		}
+	}
+	return CategoryAttribute{}, false
+}
+
+// formatInt leaves unset IDs empty
+func formatInt(v int) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.Itoa(v)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func parseCSVInt(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an integer", s)
+	}
+	return v, nil
+}
+
+// parseCSVFloat also accepts a decimal comma as written by Turkish Excel
+func parseCSVFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	return v, nil
+}
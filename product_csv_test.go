@@ -0,0 +1,129 @@
+package trendyol_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestProductsCSVRoundTrip ürünlerin özellik adlarıyla yazılıp aynı şekilde
+// geri okunduğunu ve doğrudan Create ile gönderilebildiğini doğrular.
+func TestProductsCSVRoundTrip(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	p := fixtureProduct("CSV-1")
+	p.Description = "Çok satırlı\naçıklama, virgüllü"
+	p.Images = append(p.Images, trendyol.ProductImage{URL: "https://example.com/2.jpg"})
+	p.Attributes = append(p.Attributes, trendyol.ProductAttribute{AttributeID: trendyoltest.FixtureColorAttribute, CustomAttributeValue: "Antrasit"})
+	p.DeliveryOption = &trendyol.DeliveryOption{DeliveryDuration: 1, FastDeliveryType: "SAME_DAY_SHIPPING"}
+	p.CargoCompanyID = 10
+
+	var buf bytes.Buffer
+	opts := &trendyol.CSVOptions{Comma: ';', BOM: true, Categories: client.Categories}
+	if err := trendyol.WriteProductsCSV(ctx, &buf, []trendyol.Product{p}, opts); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "\ufeffbarcode;title;") || !strings.Contains(out, "Menşei=TR; Renk=Antrasit") {
+		t.Fatalf("beklenmeyen çıktı:\n%s", out)
+	}
+
+	products, rowErrs, err := trendyol.ReadProductsCSV(ctx, &buf, opts)
+	if err != nil || len(rowErrs) != 0 {
+		t.Fatalf("okuma hatası: %v %v", err, rowErrs)
+	}
+	if len(products) != 1 || !reflect.DeepEqual(products[0], p) {
+		t.Fatalf("geri okunan ürün farklı:\n%+v\n%+v", products[0], p)
+	}
+
+	batch, err := client.Products.Create(ctx, products)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Products.WaitForBatch(ctx, batch.BatchRequestID, fastWait)
+	if err != nil || len(res.Failures) != 0 {
+		t.Fatalf("CSV'den gelen ürün oluşturulamadı: %v %v", err, res.Failures)
+	}
+}
+
+// TestReadProductsCSVRowErrors hatalı satırların atlanıp satır numarası ve
+// sütunla raporlandığını doğrular.
+func TestReadProductsCSVRowErrors(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	in := strings.Join([]string{
+		"barcode,title,categoryId,salePrice,attributes",
+		"OK-1,Hoodie,411,\"149,90\",Menşei=TR",
+		"BAD-1,Hoodie,411,abc,Menşei=TR",
+		"BAD-2,Hoodie,411,10,Menşei=DE",
+		",Hoodie,411,10,",
+		"BAD-3,Hoodie,411,10,Beden=M",
+		"BAD-4,Hoodie,999999,10,",
+		"BAD-5,Hoodie,999999,10,",
+	}, "\n")
+
+	products, rowErrs, err := trendyol.ReadProductsCSV(context.Background(), strings.NewReader(in),
+		&trendyol.CSVOptions{Categories: srv.Client().Categories})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 1 || products[0].SalePrice != 149.90 || products[0].Attributes[0].AttributeValueID != trendyoltest.FixtureOriginTR {
+		t.Fatalf("yalnızca OK-1 okunmalıydı: %+v", products)
+	}
+	want := []struct {
+		row    int
+		column string
+	}{{3, "salePrice"}, {4, "attributes"}, {5, "barcode"}, {6, "attributes"}, {7, "categoryId"}, {8, "categoryId"}}
+	if len(rowErrs) != len(want) {
+		t.Fatalf("beklenmeyen satır hataları: %v", rowErrs)
+	}
+	for i, w := range want {
+		if rowErrs[i].Row != w.row || rowErrs[i].Column != w.column {
+			t.Errorf("hata %d = %v, beklenen satır %d sütun %s", i, rowErrs[i], w.row, w.column)
+		}
+	}
+	if !errors.Is(rowErrs[4], trendyol.ErrNotFound) {
+		t.Errorf("bilinmeyen kategori ErrNotFound ile sarılmalıydı: %v", rowErrs[4])
+	}
+	if calls := srv.Calls(trendyol.EndpointGetCategoryAttributesKey); len(calls) != 2 {
+		t.Errorf("her kategori bir kez sorgulanmalıydı, %d istek atıldı", len(calls))
+	}
+}
+
+// TestProductsCSVEscaping ayraç içeren değerlerin ve sayısal serbest
+// değerlerin değer ID'leriyle karışmadan geri okunduğunu doğrular.
+func TestProductsCSVEscaping(t *testing.T) {
+	ctx := context.Background()
+	p := fixtureProduct("CSV-2")
+	p.Images = []trendyol.ProductImage{{URL: "https://example.com/a|b.jpg#1"}, {URL: `https://example.com/c\d.jpg`}}
+	p.Attributes = []trendyol.ProductAttribute{
+		{AttributeID: 1, AttributeValueID: 42},
+		{AttributeID: 2, CustomAttributeValue: "42"},
+		{AttributeID: 3, CustomAttributeValue: `%60 pamuk; %40 poli|ester = #1 \ kalite`},
+	}
+
+	var buf bytes.Buffer
+	if err := trendyol.WriteProductsCSV(ctx, &buf, []trendyol.Product{p}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.Contains(out, "1=#42; 2=42; ") {
+		t.Fatalf("değer ID'si işaretle yazılmalıydı:\n%s", out)
+	}
+
+	products, rowErrs, err := trendyol.ReadProductsCSV(ctx, &buf, nil)
+	if err != nil || len(rowErrs) != 0 {
+		t.Fatalf("okuma hatası: %v %v", err, rowErrs)
+	}
+	if len(products) != 1 || !reflect.DeepEqual(products[0].Images, p.Images) || !reflect.DeepEqual(products[0].Attributes, p.Attributes) {
+		t.Fatalf("geri okunan ürün farklı:\n%+v\n%+v", products[0], p)
+	}
+}
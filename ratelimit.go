@@ -0,0 +1,192 @@
+package trendyol
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limit describes a token bucket: sustained requests per minute plus the
+// number of requests that may be sent at once after an idle period
+type Limit struct {
+	RequestsPerMinute int
+	Burst             int // defaults to 1 when zero
+}
+
+// RateLimiter is a goroutine-free token bucket limiter. Tokens are refilled
+// lazily from the elapsed time and waiters sleep exactly until their token
+// becomes available.
+//
+// Requests use the default bucket unless their endpoint key was assigned to a
+// group with SetEndpointLimit. A RateLimiter may be shared by several Client
+// instances of the same seller via WithRateLimiter.
+type RateLimiter struct {
+	mu        sync.Mutex
+	def       *bucket
+	endpoints map[string]*bucket
+	now       func() time.Time
+}
+
+// bucket is a single token bucket; a nil bucket does not limit
+type bucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter whose default bucket allows
+// requestsPerMinute with the given burst. A non-positive rate disables the
+// default limit.
+func NewRateLimiter(requestsPerMinute, burst int) *RateLimiter {
+	rl := &RateLimiter{
+		endpoints: map[string]*bucket{},
+		now:       time.Now,
+	}
+	rl.def = rl.newBucket(Limit{RequestsPerMinute: requestsPerMinute, Burst: burst})
+	return rl
+}
+
+func (rl *RateLimiter) newBucket(l Limit) *bucket {
+	if l.RequestsPerMinute <= 0 {
+		return nil
+	}
+	if l.Burst <= 0 {
+		l.Burst = 1
+	}
+	return &bucket{
+		rate:   float64(l.RequestsPerMinute) / 60,
+		burst:  float64(l.Burst),
+		tokens: float64(l.Burst),
+		last:   rl.now(),
+	}
+}
+
+// SetEndpointLimit gives the endpoint keys their own bucket, shared among
+// them and independent of the default bucket. Use it for endpoint groups that
+// Trendyol throttles separately, e.g. product, order and inventory endpoints.
+func (rl *RateLimiter) SetEndpointLimit(l Limit, keys ...string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	b := rl.newBucket(l)
+	for _, k := range keys {
+		rl.endpoints[k] = b
+	}
+}
+
+func (rl *RateLimiter) bucketFor(key string) *bucket {
+	if b, ok := rl.endpoints[key]; ok {
+		return b
+	}
+	return rl.def
+}
+
+// Reservation is a token taken from a bucket ahead of time
+type Reservation struct {
+	rl       *RateLimiter
+	b        *bucket
+	delay    time.Duration
+	canceled bool
+}
+
+// Delay returns how long the caller must wait before sending the request
+func (r *Reservation) Delay() time.Duration {
+	return r.delay
+}
+
+// Cancel returns the reserved token to its bucket
+func (r *Reservation) Cancel() {
+	if r.b == nil {
+		return
+	}
+	r.rl.mu.Lock()
+	defer r.rl.mu.Unlock()
+	if r.canceled {
+		return
+	}
+	r.canceled = true
+	r.b.advance(r.rl.now())
+	r.b.tokens++
+	if r.b.tokens > r.b.burst {
+		r.b.tokens = r.b.burst
+	}
+}
+
+// Reserve takes a token for the endpoint key immediately and reports how long
+// the caller has to wait before using it
+func (rl *RateLimiter) Reserve(endpointKey string) *Reservation {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	b := rl.bucketFor(endpointKey)
+	r := &Reservation{rl: rl, b: b}
+	if b == nil {
+		return r
+	}
+	b.advance(rl.now())
+	b.tokens--
+	if b.tokens < 0 {
+		r.delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	return r
+}
+
+// Wait blocks until a token for the endpoint key is available or ctx is done
+func (rl *RateLimiter) Wait(ctx context.Context, endpointKey string) error {
+	r := rl.Reserve(endpointKey)
+	if r.delay <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < r.delay {
+		r.Cancel()
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(r.delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
+
+// advance refills the bucket for the time elapsed since the last update
+func (b *bucket) advance(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+}
+
+// WithRateLimiter makes the client use a shared limiter
+func WithRateLimiter(rl *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = rl
+	}
+}
+
+// WithEndpointRateLimit limits the given endpoint keys with their own bucket
+// on the client's limiter. When the limiter is shared, the limit applies to
+// every client using it.
+func WithEndpointRateLimit(l Limit, keys ...string) ClientOption {
+	return func(c *Client) {
+		c.endpointLimits = append(c.endpointLimits, endpointLimit{limit: l, keys: keys})
+	}
+}
+
+type endpointLimit struct {
+	limit Limit
+	keys  []string
+}
+
+// RateLimiter returns the limiter used by the client, e.g. to share it with
+// another client via WithRateLimiter
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.rateLimiter
+}
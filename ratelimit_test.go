@@ -0,0 +1,119 @@
+package trendyol_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestRateLimiterReserve kova kapasitesi (burst) tükendikten sonra bir sonraki
+// jetonun tam olarak ne zaman hazır olacağının hesaplandığını doğrular.
+func TestRateLimiterReserve(t *testing.T) {
+	rl := trendyol.NewRateLimiter(60, 2)
+
+	for i := 0; i < 2; i++ {
+		if d := rl.Reserve("any").Delay(); d != 0 {
+			t.Fatalf("burst içinde bekleme olmamalı, gelen %v", d)
+		}
+	}
+	r := rl.Reserve("any")
+	if d := r.Delay(); d < 900*time.Millisecond || d > time.Second {
+		t.Errorf("yaklaşık 1s bekleme beklendi, gelen %v", d)
+	}
+	r.Cancel()
+	if d := rl.Reserve("any").Delay(); d < 900*time.Millisecond || d > time.Second {
+		t.Errorf("iptal edilen jeton iade edilmeliydi, gelen %v", d)
+	}
+}
+
+// TestRateLimiterEndpointGroups uç nokta gruplarının varsayılan kovadan bağımsız
+// sınırlandığını doğrular.
+func TestRateLimiterEndpointGroups(t *testing.T) {
+	rl := trendyol.NewRateLimiter(60, 1)
+	rl.SetEndpointLimit(trendyol.Limit{RequestsPerMinute: 60, Burst: 1},
+		trendyol.EndpointGetProductsKey, trendyol.EndpointCreateProductsKey)
+
+	if d := rl.Reserve(trendyol.EndpointGetOrdersKey).Delay(); d != 0 {
+		t.Fatalf("varsayılan kova dolu olmalıydı: %v", d)
+	}
+	if d := rl.Reserve(trendyol.EndpointGetProductsKey).Delay(); d != 0 {
+		t.Errorf("ürün grubu sipariş isteklerinden etkilenmemeli: %v", d)
+	}
+	if d := rl.Reserve(trendyol.EndpointCreateProductsKey).Delay(); d == 0 {
+		t.Error("aynı gruptaki uç noktalar kovayı paylaşmalı")
+	}
+	if d := rl.Reserve(""); d.Delay() == 0 {
+		t.Error("anahtarsız istekler varsayılan kovayı kullanmalı")
+	}
+}
+
+// TestRateLimiterWaitContext context süresi jetondan önce dolduğunda beklemeden
+// döndüğünü doğrular.
+func TestRateLimiterWaitContext(t *testing.T) {
+	rl := trendyol.NewRateLimiter(1, 1)
+	ctx := context.Background()
+	if err := rl.Wait(ctx, "k"); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := rl.Wait(ctx, "k"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("DeadlineExceeded beklendi: %v", err)
+	}
+	if time.Since(start) > 5*time.Millisecond {
+		t.Error("karşılanamayacak bekleme hemen reddedilmeliydi")
+	}
+}
+
+// TestSharedRateLimiter aynı satıcı için oluşturulan istemcilerin tek sınırlayıcıyı
+// paylaşabildiğini doğrular.
+func TestSharedRateLimiter(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+
+	shared := trendyol.NewRateLimiter(600, 2)
+	a := srv.Client(trendyol.WithRateLimiter(shared))
+	b := srv.Client(trendyol.WithRateLimiter(shared))
+	if a.RateLimiter() != b.RateLimiter() {
+		t.Fatal("istemciler aynı sınırlayıcıyı kullanmalı")
+	}
+
+	ctx := context.Background()
+	if _, _, err := a.Products.List(ctx, 0, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := b.Orders.List(ctx, trendyol.ListOrdersOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if d := shared.Reserve("").Delay(); d == 0 {
+		t.Error("iki istemcinin istekleri ortak kovadan düşmeliydi")
+	}
+}
+
+// TestRateLimiterRetries yeniden denemelerin de hız sınırı kotasından
+// düştüğünü doğrular.
+func TestRateLimiterRetries(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	srv.FailNext(trendyol.EndpointGetProductsKey, 1, http.StatusServiceUnavailable, trendyol.ErrCodeInternal, "down")
+
+	rl := trendyol.NewRateLimiter(60, 2)
+	policy := trendyol.RetryPolicyFunc(func(req *trendyol.Request, attempt int, err error) (time.Duration, bool) {
+		return time.Millisecond, attempt < 2
+	})
+	client := srv.Client(trendyol.WithRateLimiter(rl), trendyol.WithRetryPolicy(policy))
+
+	if _, _, err := client.Products.List(context.Background(), 0, 10); err != nil {
+		t.Fatal(err)
+	}
+	if d := rl.Reserve(trendyol.EndpointGetProductsKey).Delay(); d == 0 {
+		t.Error("iki deneme kovadaki iki jetonu da tüketmeliydi")
+	}
+}
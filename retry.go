@@ -0,0 +1,148 @@
+package trendyol
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy decides whether a failed attempt is retried and how long to wait
+// before the next one. Retry is called after every failed attempt, starting at
+// attempt 1; req carries the status code and headers of the last response.
+type RetryPolicy interface {
+	Retry(req *Request, attempt int, err error) (delay time.Duration, retry bool)
+}
+
+// RetryPolicyFunc adapts a function to RetryPolicy
+type RetryPolicyFunc func(req *Request, attempt int, err error) (time.Duration, bool)
+
+// Retry implements RetryPolicy
+func (f RetryPolicyFunc) Retry(req *Request, attempt int, err error) (time.Duration, bool) {
+	return f(req, attempt, err)
+}
+
+// WithRetryPolicy replaces the default retry policy. WithRetryConfig has no
+// effect once a custom policy is set.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = p
+	}
+}
+
+// DefaultRetryPolicy retries rate limited (429) and server (5xx) responses as
+// well as network timeouts and connection resets, using exponential backoff
+// with jitter. Delays sent by the gateway in Retry-After or X-RateLimit-Reset
+// take precedence over the computed backoff.
+//
+// Non-idempotent requests (POST, PATCH) are only retried after a 429, which
+// the gateway returns before processing, unless Request.Idempotent is set.
+type DefaultRetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration // delay before the first retry, doubled afterwards
+	MaxDelay   time.Duration // longest delay accepted; longer server delays stop retrying
+	Jitter     float64       // fraction of the delay added or removed at random, 0..1
+}
+
+// Retry implements RetryPolicy
+func (p *DefaultRetryPolicy) Retry(req *Request, attempt int, err error) (time.Duration, bool) {
+	if attempt > p.MaxRetries {
+		return 0, false
+	}
+
+	var apiErr *Error
+	isAPIErr := errors.As(err, &apiErr)
+	switch {
+	case isAPIErr && apiErr.StatusCode == http.StatusTooManyRequests:
+		// Rejected by the gateway before processing, safe for every method
+	case isAPIErr && apiErr.StatusCode >= 500:
+		if !req.Idempotent && !idempotentMethod(req.Method) {
+			return 0, false
+		}
+	case !isAPIErr && IsNetworkError(err):
+		if !req.Idempotent && !idempotentMethod(req.Method) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if d, ok := serverDelay(req.ResponseHeader, time.Now()); ok {
+		if p.MaxDelay > 0 && d > p.MaxDelay {
+			return 0, false
+		}
+		// Only add jitter on top of the server delay, never below it
+		return d + time.Duration(rand.Float64()*p.Jitter*float64(d)), true
+	}
+
+	delay := p.BaseDelay * time.Duration(1<<(attempt-1))
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(delay))
+	}
+	return delay, true
+}
+
+// IsNetworkError reports whether err is a transport level failure such as a
+// timeout, connection reset or unexpected EOF
+func IsNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+func idempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// serverDelay reads Retry-After or, when the quota is exhausted, X-RateLimit-Reset
+func serverDelay(h http.Header, now time.Time) (time.Duration, bool) {
+	if h == nil {
+		return 0, false
+	}
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+	if strings.TrimSpace(h.Get("X-RateLimit-Remaining")) == "0" {
+		if v := strings.TrimSpace(h.Get("X-RateLimit-Reset")); v != "" {
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				// Large values are unix timestamps, small ones seconds to wait
+				if n > 1e9 {
+					return nonNegative(time.Unix(n, 0).Sub(now)), true
+				}
+				return time.Duration(n) * time.Second, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
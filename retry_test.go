@@ -0,0 +1,123 @@
+package trendyol_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestDefaultRetryPolicy sunucu gecikmelerinin, idempotent olmayan metotların ve
+// ağ hatalarının doğru değerlendirildiğini doğrular.
+func TestDefaultRetryPolicy(t *testing.T) {
+	p := &trendyol.DefaultRetryPolicy{MaxRetries: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Minute}
+	tooMany := &trendyol.Error{StatusCode: http.StatusTooManyRequests}
+	server := &trendyol.Error{StatusCode: http.StatusBadGateway}
+
+	get := &trendyol.Request{Method: http.MethodGet, ResponseHeader: http.Header{"Retry-After": {"7"}}}
+	if d, ok := p.Retry(get, 1, tooMany); !ok || d != 7*time.Second {
+		t.Errorf("Retry-After dikkate alınmadı: %v %v", d, ok)
+	}
+
+	reset := &trendyol.Request{Method: http.MethodGet, ResponseHeader: http.Header{
+		"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"3"},
+	}}
+	if d, ok := p.Retry(reset, 1, tooMany); !ok || d != 3*time.Second {
+		t.Errorf("X-RateLimit-Reset dikkate alınmadı: %v %v", d, ok)
+	}
+
+	if d, ok := p.Retry(&trendyol.Request{Method: http.MethodGet}, 3, server); !ok || d != 400*time.Millisecond {
+		t.Errorf("üstel bekleme beklendi: %v %v", d, ok)
+	}
+	if _, ok := p.Retry(&trendyol.Request{Method: http.MethodGet}, 4, server); ok {
+		t.Error("azami deneme sayısı aşıldı")
+	}
+	if _, ok := p.Retry(&trendyol.Request{Method: http.MethodGet}, 1, &trendyol.Error{StatusCode: 400}); ok {
+		t.Error("4xx yeniden denenmemeli")
+	}
+
+	post := &trendyol.Request{Method: http.MethodPost}
+	if _, ok := p.Retry(post, 1, server); ok {
+		t.Error("POST 5xx sonrası yeniden denenmemeli")
+	}
+	if _, ok := p.Retry(post, 1, tooMany); !ok {
+		t.Error("POST 429 sonrası yeniden denenmeli")
+	}
+	if _, ok := p.Retry(&trendyol.Request{Method: http.MethodPost, Idempotent: true}, 1, server); !ok {
+		t.Error("güvenli işaretlenmiş POST yeniden denenmeli")
+	}
+
+	reset2 := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	if _, ok := p.Retry(&trendyol.Request{Method: http.MethodGet}, 1, reset2); !ok {
+		t.Error("bağlantı sıfırlanması yeniden denenmeli")
+	}
+	if _, ok := p.Retry(&trendyol.Request{Method: http.MethodGet}, 1, errors.New("failed to marshal request body")); ok {
+		t.Error("ağ dışı hatalar yeniden denenmemeli")
+	}
+
+	long := &trendyol.Request{Method: http.MethodGet, ResponseHeader: http.Header{"Retry-After": {"3600"}}}
+	if _, ok := p.Retry(long, 1, tooMany); ok {
+		t.Error("MaxDelay üzerindeki sunucu gecikmesinde vazgeçilmeli")
+	}
+}
+
+// TestRetryHonorsServerHeaders istemcinin 429 yanıtındaki Retry-After başlığına
+// göre beklediğini ve POST isteklerini yalnızca güvenli durumlarda tekrarladığını doğrular.
+func TestRetryHonorsServerHeaders(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client(trendyol.WithRetryConfig(2, time.Millisecond))
+	ctx := context.Background()
+
+	srv.FailNextWithHeader(trendyol.EndpointGetProductsKey, 1, http.StatusTooManyRequests, trendyol.ErrCodeRateLimit, "slow down",
+		http.Header{"Retry-After": {"1"}})
+	start := time.Now()
+	if _, _, err := client.Products.List(ctx, 0, 10); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Retry-After süresi beklenmedi: %v", elapsed)
+	}
+
+	srv.FailNext(trendyol.EndpointCreateProductsKey, 1, http.StatusBadGateway, trendyol.ErrCodeInternal, "bad gateway")
+	if _, err := client.Products.Create(ctx, []trendyol.Product{fixtureProduct("R-1")}); err == nil {
+		t.Fatal("ürün oluşturma 502 sonrası tekrar edilmemeliydi")
+	}
+	if n := len(srv.Calls(trendyol.EndpointCreateProductsKey)); n != 1 {
+		t.Errorf("tek istek beklendi, giden %d", n)
+	}
+
+	srv.FailNext(trendyol.EndpointUpdatePriceInventoryKey, 1, http.StatusBadGateway, trendyol.ErrCodeInternal, "bad gateway")
+	if _, err := client.PriceInventory.Update(ctx, []trendyol.PriceInventoryItem{{Barcode: "R-1", Quantity: 1, SalePrice: 10, ListPrice: 10}}); err != nil {
+		t.Fatalf("stok güncellemesi tekrar edilmeliydi: %v", err)
+	}
+}
+
+// TestWithRetryPolicy özel politikanın her başarısız denemede çağrıldığını doğrular.
+func TestWithRetryPolicy(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	srv.FailNext(trendyol.EndpointGetProductsKey, 3, http.StatusServiceUnavailable, trendyol.ErrCodeInternal, "down")
+
+	var attempts []int
+	policy := trendyol.RetryPolicyFunc(func(req *trendyol.Request, attempt int, err error) (time.Duration, bool) {
+		attempts = append(attempts, attempt)
+		return time.Millisecond, attempt < 2
+	})
+	client := srv.Client(trendyol.WithRetryPolicy(policy))
+
+	_, _, err := client.Products.List(context.Background(), 0, 10)
+	var apiErr *trendyol.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("503 bekleniyordu: %v", err)
+	}
+	if len(attempts) != 2 || attempts[1] != 2 {
+		t.Errorf("beklenmeyen denemeler: %v", attempts)
+	}
+}
@@ -0,0 +1,384 @@
+package trendyol
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SettlementStatus is the outcome of reconciling one order line
+type SettlementStatus string
+
+// Settlement reconciliation statuses
+const (
+	SettlementMatched   SettlementStatus = "MATCHED"
+	SettlementPending   SettlementStatus = "PENDING" // not delivered yet and nothing settled
+	SettlementUnpaid    SettlementStatus = "UNPAID"  // delivered but no settlement found
+	SettlementUnderpaid SettlementStatus = "UNDERPAID"
+	SettlementOverpaid  SettlementStatus = "OVERPAID"
+	SettlementOrphan    SettlementStatus = "ORPHAN" // settled but no matching order line
+)
+
+// SettlementLine is the reconciliation result of one order line. Orphan
+// settlements are grouped by order number and barcode into lines without an
+// OrderLineID.
+type SettlementLine struct {
+	OrderNumber   string
+	PackageID     int64
+	OrderLineID   int64
+	Barcode       string
+	Quantity      int
+	PackageStatus PackageStatus
+
+	ExpectedAmount     float64
+	ExpectedCommission float64
+	ExpectedRevenue    float64
+	ActualAmount       float64
+	ActualCommission   float64
+	ActualRevenue      float64
+
+	Status      SettlementStatus
+	Settlements []Settlement
+}
+
+// RevenueDiff returns the actual minus the expected seller revenue
+func (l SettlementLine) RevenueDiff() float64 {
+	return roundCents(l.ActualRevenue - l.ExpectedRevenue)
+}
+
+// CommissionDiff returns the actual minus the expected commission
+func (l SettlementLine) CommissionDiff() float64 {
+	return roundCents(l.ActualCommission - l.ExpectedCommission)
+}
+
+// SettlementReport is the result of SettlementReconciler.Reconcile. Lines are
+// sorted by order number, package ID and order line ID with orphans last.
+type SettlementReport struct {
+	Start time.Time
+	End   time.Time
+	Lines []SettlementLine
+}
+
+// Filter returns the lines having one of statuses
+func (r *SettlementReport) Filter(statuses ...SettlementStatus) []SettlementLine {
+	want := make(map[SettlementStatus]bool, len(statuses))
+	for _, s := range statuses {
+		want[s] = true
+	}
+	var out []SettlementLine
+	for _, l := range r.Lines {
+		if want[l.Status] {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// Counts returns the number of lines per status
+func (r *SettlementReport) Counts() map[SettlementStatus]int {
+	counts := map[SettlementStatus]int{}
+	for _, l := range r.Lines {
+		counts[l.Status]++
+	}
+	return counts
+}
+
+// SettlementCSVColumns is the column schema written by SettlementReport.WriteCSV
+var SettlementCSVColumns = []string{
+	"orderNumber", "packageId", "orderLineId", "barcode", "quantity", "packageStatus", "status",
+	"expectedAmount", "actualAmount", "expectedCommission", "actualCommission",
+	"expectedRevenue", "actualRevenue", "revenueDiff",
+}
+
+// WriteCSV writes one row per line. Only Comma and BOM of opts are used.
+func (r *SettlementReport) WriteCSV(w io.Writer, opts *CSVOptions) error {
+	var o CSVOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.BOM {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return err
+		}
+	}
+	cw := csv.NewWriter(w)
+	if o.Comma != 0 {
+		cw.Comma = o.Comma
+	}
+	if err := cw.Write(SettlementCSVColumns); err != nil {
+		return err
+	}
+	for _, l := range r.Lines {
+		var packageID, lineID string
+		if l.PackageID != 0 {
+			packageID = strconv.FormatInt(l.PackageID, 10)
+		}
+		if l.OrderLineID != 0 {
+			lineID = strconv.FormatInt(l.OrderLineID, 10)
+		}
+		row := []string{
+			l.OrderNumber, packageID, lineID, l.Barcode, formatInt(l.Quantity), string(l.PackageStatus), string(l.Status),
+			formatMoney(l.ExpectedAmount), formatMoney(l.ActualAmount),
+			formatMoney(l.ExpectedCommission), formatMoney(l.ActualCommission),
+			formatMoney(l.ExpectedRevenue), formatMoney(l.ActualRevenue), formatMoney(l.RevenueDiff()),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// SettlementOption configures a SettlementReconciler
+type SettlementOption func(*SettlementReconciler)
+
+// WithSettlementTolerance sets the largest revenue difference still reported
+// as matched, default 0.01
+func WithSettlementTolerance(tolerance float64) SettlementOption {
+	return func(r *SettlementReconciler) {
+		r.tolerance = tolerance
+	}
+}
+
+// WithCommissionRates sets the commission percentage expected for a line.
+// Returning false falls back to the commissionRate of the line's settlements;
+// when neither is known the actual commission is taken as expected.
+func WithCommissionRates(rate func(o Order, l OrderLine) (float64, bool)) SettlementOption {
+	return func(r *SettlementReconciler) {
+		r.rate = rate
+	}
+}
+
+// SettlementReconciler matches settlements to order lines
+type SettlementReconciler struct {
+	finance   FinanceService
+	orders    OrderService
+	tolerance float64
+	rate      func(Order, OrderLine) (float64, bool)
+}
+
+// NewSettlementReconciler creates a reconciler, usually from client.Finance
+// and client.Orders
+func NewSettlementReconciler(finance FinanceService, orders OrderService, opts ...SettlementOption) *SettlementReconciler {
+	r := &SettlementReconciler{
+		finance:   finance,
+		orders:    orders,
+		tolerance: 0.01,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Reconcile fetches the settlements and the packages modified between start
+// and end, matches settlements to order lines by order number and barcode and
+// compares the settled amounts with the line totals. Orders of settlements
+// outside the package window are looked up by order number.
+//
+// Settlement amounts are summed as signed values, so a return is expected to
+// cancel its sale. Cancelled, returned and unsupplied lines are expected to
+// net to zero, every other line to its price minus the seller discount.
+func (r *SettlementReconciler) Reconcile(ctx context.Context, start, end time.Time) (*SettlementReport, error) {
+	var settlements []Settlement
+	err := r.finance.ForEachSettlementInRange(ctx, SettlementQuery{StartDate: start, EndDate: end}, func(s Settlement) error {
+		if s.OrderNumber != "" {
+			settlements = append(settlements, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch settlements: %w", err)
+	}
+
+	packages := map[int64]Order{}
+	collect := func(o Order) error {
+		packages[o.ID] = o
+		return nil
+	}
+	for from := start; from.Before(end); from = from.Add(maxOrderWindow) {
+		to := from.Add(maxOrderWindow)
+		if to.After(end) {
+			to = end
+		}
+		from := from
+		opts := ListOrdersOptions{StartDate: &from, EndDate: &to, Size: 200}
+		if err := r.orders.ForEach(ctx, opts, collect); err != nil {
+			return nil, fmt.Errorf("failed to fetch orders: %w", err)
+		}
+	}
+	known := map[string]bool{}
+	for _, o := range packages {
+		known[o.OrderNumber] = true
+	}
+	for _, s := range settlements {
+		if known[s.OrderNumber] {
+			continue
+		}
+		known[s.OrderNumber] = true
+		opts := ListOrdersOptions{OrderNumber: s.OrderNumber, Size: 50}
+		if err := r.orders.ForEach(ctx, opts, collect); err != nil {
+			return nil, fmt.Errorf("failed to fetch order %s: %w", s.OrderNumber, err)
+		}
+	}
+
+	report := &SettlementReport{Start: start, End: end}
+	report.Lines = r.match(sortedPackages(packages), settlements)
+	return report, nil
+}
+
+func sortedPackages(packages map[int64]Order) []Order {
+	out := make([]Order, 0, len(packages))
+	for _, o := range packages {
+		out = append(out, o)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].OrderNumber != out[j].OrderNumber {
+			return out[i].OrderNumber < out[j].OrderNumber
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+func (r *SettlementReconciler) match(orders []Order, settlements []Settlement) []SettlementLine {
+	var lines []SettlementLine
+	var owners []Order // order of each line, for commission rates
+	byPackage := map[string]int{}
+	byOrder := map[string]int{}
+	linesOf := map[string][]int{}
+	for _, o := range orders {
+		for _, ol := range o.Lines {
+			i := len(lines)
+			lines = append(lines, SettlementLine{
+				OrderNumber:    o.OrderNumber,
+				PackageID:      o.ID,
+				OrderLineID:    ol.ID,
+				Barcode:        ol.Barcode,
+				Quantity:       ol.Quantity,
+				PackageStatus:  o.Status,
+				ExpectedAmount: expectedLineAmount(o, ol),
+			})
+			owners = append(owners, o)
+			byPackage[fmt.Sprintf("%d|%s", o.ID, ol.Barcode)] = i
+			if _, ok := byOrder[o.OrderNumber+"|"+ol.Barcode]; !ok {
+				byOrder[o.OrderNumber+"|"+ol.Barcode] = i
+			}
+			linesOf[o.OrderNumber] = append(linesOf[o.OrderNumber], i)
+		}
+	}
+
+	var orphans []SettlementLine
+	orphanOf := map[string]int{}
+	for _, s := range settlements {
+		i, ok := byPackage[fmt.Sprintf("%d|%s", s.ShipmentPackageID, s.Barcode)]
+		if !ok {
+			i, ok = byOrder[s.OrderNumber+"|"+s.Barcode]
+		}
+		if !ok && s.Barcode == "" && len(linesOf[s.OrderNumber]) == 1 {
+			i, ok = linesOf[s.OrderNumber][0], true
+		}
+		if ok {
+			lines[i].Settlements = append(lines[i].Settlements, s)
+			continue
+		}
+		key := s.OrderNumber + "|" + s.Barcode
+		j, seen := orphanOf[key]
+		if !seen {
+			j = len(orphans)
+			orphanOf[key] = j
+			orphans = append(orphans, SettlementLine{OrderNumber: s.OrderNumber, PackageID: s.ShipmentPackageID, Barcode: s.Barcode})
+		}
+		orphans[j].Settlements = append(orphans[j].Settlements, s)
+	}
+
+	for i := range lines {
+		r.settle(&lines[i], owners[i])
+	}
+	for i := range orphans {
+		sumSettlements(&orphans[i])
+		orphans[i].Status = SettlementOrphan
+	}
+	return append(lines, orphans...)
+}
+
+// settle fills the actual and expected figures and the status of l
+func (r *SettlementReconciler) settle(l *SettlementLine, o Order) {
+	sumSettlements(l)
+
+	rate, ok := 0.0, false
+	if r.rate != nil {
+		for _, ol := range o.Lines {
+			if ol.ID == l.OrderLineID {
+				rate, ok = r.rate(o, ol)
+				break
+			}
+		}
+	}
+	for _, s := range l.Settlements {
+		if ok {
+			break
+		}
+		rate, ok = s.CommissionRate, s.CommissionRate != 0
+	}
+	if ok {
+		l.ExpectedCommission = roundCents(l.ExpectedAmount * rate / 100)
+	} else {
+		l.ExpectedCommission = l.ActualCommission
+	}
+	l.ExpectedRevenue = roundCents(l.ExpectedAmount - l.ExpectedCommission)
+
+	diff := l.RevenueDiff()
+	switch {
+	case len(l.Settlements) == 0 && l.ExpectedAmount == 0:
+		l.Status = SettlementMatched
+	case len(l.Settlements) == 0 && l.PackageStatus == StatusDelivered:
+		l.Status = SettlementUnpaid
+	case len(l.Settlements) == 0:
+		l.Status = SettlementPending
+	case diff < -r.tolerance:
+		l.Status = SettlementUnderpaid
+	case diff > r.tolerance:
+		l.Status = SettlementOverpaid
+	default:
+		l.Status = SettlementMatched
+	}
+}
+
+func sumSettlements(l *SettlementLine) {
+	for _, s := range l.Settlements {
+		l.ActualAmount += s.Amount
+		l.ActualCommission += s.CommissionAmount
+		l.ActualRevenue += s.SellerRevenue
+	}
+	l.ActualAmount = roundCents(l.ActualAmount)
+	l.ActualCommission = roundCents(l.ActualCommission)
+	l.ActualRevenue = roundCents(l.ActualRevenue)
+}
+
+// expectedLineAmount is what the seller should be paid for ol before
+// commission. Amount already covers every unit of the line; Trendyol funded
+// discounts are not deducted.
+func expectedLineAmount(o Order, ol OrderLine) float64 {
+	for _, status := range []PackageStatus{o.Status, PackageStatus(ol.OrderLineItemStatusName)} {
+		switch status {
+		case StatusCancelled, StatusReturned, StatusUnSupplied:
+			return 0
+		}
+	}
+	return roundCents(ol.Amount - ol.Discount)
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func formatMoney(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,128 @@
+package trendyol_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+// TestSettlementReconciler hakedişlerin sipariş satırlarıyla eşleştirildiğini,
+// eksik, az, fazla ödenen ve sahipsiz kayıtların işaretlendiğini ve raporun
+// CSV olarak yazıldığını doğrular.
+func TestSettlementReconciler(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	start := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	at := func(day int) int64 { return start.AddDate(0, 0, day).UnixMilli() }
+
+	order := func(number string, status trendyol.PackageStatus, modified int64, line trendyol.OrderLine) trendyol.Order {
+		return srv.AddOrder(trendyol.Order{OrderNumber: number, Status: status, LastModifiedDate: modified, Lines: []trendyol.OrderLine{line}})
+	}
+	// Amount is the line total, Price the unit price
+	paid := order("SR-1", trendyol.StatusDelivered, at(1), trendyol.OrderLine{Barcode: "B-1", Quantity: 2, Price: 100, Amount: 200, Discount: 20})
+	order("SR-2", trendyol.StatusDelivered, at(3), trendyol.OrderLine{Barcode: "B-2", Quantity: 1, Amount: 50})
+	order("SR-3", trendyol.StatusDelivered, at(20), trendyol.OrderLine{Barcode: "B-3", Quantity: 1, Amount: 30})
+	order("SR-4", trendyol.StatusShipped, at(25), trendyol.OrderLine{Barcode: "B-4", Quantity: 1, Amount: 10})
+	order("SR-5", trendyol.StatusReturned, at(26), trendyol.OrderLine{Barcode: "B-5", Quantity: 1, Amount: 40})
+	// Modified before the range, found through its settlement
+	order("SR-OLD", trendyol.StatusDelivered, start.AddDate(0, -1, 0).UnixMilli(), trendyol.OrderLine{Barcode: "B-6", Quantity: 1, Amount: 70})
+
+	srv.AddSettlements(
+		trendyol.Settlement{SettlementDate: at(2), TransactionType: "Sale", OrderNumber: "SR-1", ShipmentPackageID: paid.ID, Barcode: "B-1", Amount: 180, CommissionRate: 10, CommissionAmount: 18, SellerRevenue: 162},
+		trendyol.Settlement{SettlementDate: at(4), TransactionType: "Sale", OrderNumber: "SR-2", Barcode: "B-2", Amount: 50, CommissionRate: 10, CommissionAmount: 5, SellerRevenue: 40},
+		trendyol.Settlement{SettlementDate: at(5), TransactionType: "Sale", OrderNumber: "SR-OLD", Amount: 70, CommissionRate: 10, CommissionAmount: 7, SellerRevenue: 70},
+		trendyol.Settlement{SettlementDate: at(22), TransactionType: "Sale", OrderNumber: "SR-5", Barcode: "B-5", Amount: 40, CommissionAmount: 4, SellerRevenue: 36},
+		trendyol.Settlement{SettlementDate: at(27), TransactionType: "Return", OrderNumber: "SR-5", Barcode: "B-5", Amount: -40, CommissionAmount: -4, SellerRevenue: -36},
+		trendyol.Settlement{SettlementDate: at(28), TransactionType: "Sale", OrderNumber: "SR-404", Barcode: "B-9", Amount: 25, CommissionAmount: 2.5, SellerRevenue: 22.5},
+		trendyol.Settlement{SettlementDate: at(29), TransactionType: "PaymentOrder", Amount: -500},
+	)
+
+	r := trendyol.NewSettlementReconciler(client.Finance, client.Orders)
+	report, err := r.Reconcile(ctx, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]trendyol.SettlementStatus{
+		"SR-1":   trendyol.SettlementMatched,
+		"SR-2":   trendyol.SettlementUnderpaid,
+		"SR-3":   trendyol.SettlementUnpaid,
+		"SR-4":   trendyol.SettlementPending,
+		"SR-5":   trendyol.SettlementMatched,
+		"SR-OLD": trendyol.SettlementOverpaid,
+		"SR-404": trendyol.SettlementOrphan,
+	}
+	if len(report.Lines) != len(want) {
+		t.Fatalf("%d satır beklendi, %d geldi: %+v", len(want), len(report.Lines), report.Lines)
+	}
+	for _, l := range report.Lines {
+		if l.Status != want[l.OrderNumber] {
+			t.Errorf("%s: statü %s, beklenen %s (%+v)", l.OrderNumber, l.Status, want[l.OrderNumber], l)
+		}
+	}
+	if last := report.Lines[len(report.Lines)-1]; last.OrderNumber != "SR-404" || last.OrderLineID != 0 {
+		t.Errorf("sahipsiz kayıt sonda olmalıydı: %+v", last)
+	}
+
+	under := report.Filter(trendyol.SettlementUnderpaid)
+	if len(under) != 1 || under[0].ExpectedRevenue != 45 || under[0].RevenueDiff() != -5 || under[0].CommissionDiff() != 0 {
+		t.Errorf("eksik ödeme hesaplanamadı: %+v", under)
+	}
+	over := report.Filter(trendyol.SettlementOverpaid)
+	if len(over) != 1 || over[0].ExpectedCommission != 7 || over[0].RevenueDiff() != 7 {
+		t.Errorf("fazla ödeme hesaplanamadı: %+v", over)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf, &trendyol.CSVOptions{Comma: ';'}); err != nil {
+		t.Fatal(err)
+	}
+	cr := csv.NewReader(&buf)
+	cr.Comma = ';'
+	rows, err := cr.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(want)+1 || rows[0][0] != "orderNumber" {
+		t.Fatalf("CSV satırları beklenmedik: %v", rows)
+	}
+	if got := rows[2]; got[0] != "SR-2" || got[6] != "UNDERPAID" || got[len(got)-1] != "-5.00" {
+		t.Errorf("CSV satırı beklenmedik: %v", got)
+	}
+}
+
+// TestSettlementReconcilerCommissionRates verilen komisyon oranının hakediş
+// kaydındaki orana tercih edildiğini doğrular.
+func TestSettlementReconcilerCommissionRates(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	day := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	srv.AddOrder(trendyol.Order{OrderNumber: "CR-1", Status: trendyol.StatusDelivered, LastModifiedDate: day.UnixMilli(),
+		Lines: []trendyol.OrderLine{{Barcode: "C-1", Quantity: 1, Amount: 200, ProductCategoryID: 411}}})
+	srv.AddSettlements(trendyol.Settlement{SettlementDate: day.UnixMilli(), TransactionType: trendyol.TransactionSale, OrderNumber: "CR-1", Barcode: "C-1",
+		Amount: 200, CommissionRate: 15, CommissionAmount: 30, SellerRevenue: 170})
+
+	r := trendyol.NewSettlementReconciler(client.Finance, client.Orders,
+		trendyol.WithCommissionRates(func(o trendyol.Order, l trendyol.OrderLine) (float64, bool) {
+			return 12, l.ProductCategoryID == 411
+		}))
+	report, err := r.Reconcile(context.Background(), day.Add(-time.Hour), day.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := report.Lines[0]
+	if l.Status != trendyol.SettlementUnderpaid || l.ExpectedCommission != 24 || l.CommissionDiff() != 6 {
+		t.Errorf("anlaşılan komisyon oranı kullanılmalıydı: %+v", l)
+	}
+}
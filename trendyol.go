@@ -29,7 +29,7 @@
 //	    ListPrice:     250.99,
 //	    SalePrice:     120.99,
 //	    CurrencyType:  "TRY",
-//	    VATRate:       18,
+//	    VATRate:       20,
 //	    Images: []trendyol.ProductImage{
 //	        {URL: "https://example.com/image.jpg"},
 //	    },
@@ -48,11 +48,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 )
 
@@ -64,15 +64,27 @@ const (
 
 // Package status constants
 const (
-	StatusAwaiting  = "Awaiting"
-	StatusCreated   = "Created"
-	StatusPicking   = "Picking"
-	StatusInvoiced  = "Invoiced"
-	StatusShipped   = "Shipped"
-	StatusDelivered = "Delivered"
-	StatusCancelled = "Cancelled"
-	StatusUnpacked  = "Unpacked"
-	StatusReturned  = "Returned"
+	StatusAwaiting   PackageStatus = "Awaiting"
+	StatusCreated    PackageStatus = "Created"
+	StatusPicking    PackageStatus = "Picking"
+	StatusInvoiced   PackageStatus = "Invoiced"
+	StatusShipped    PackageStatus = "Shipped"
+	StatusDelivered  PackageStatus = "Delivered"
+	StatusCancelled  PackageStatus = "Cancelled"
+	StatusUnpacked   PackageStatus = "Unpacked"
+	StatusReturned   PackageStatus = "Returned"
+	StatusUnSupplied PackageStatus = "UnSupplied"
+)
+
+// Batch request status constants
+const (
+	BatchStatusInProgress = "IN_PROGRESS"
+	BatchStatusCompleted  = "COMPLETED"
+	BatchStatusFailed     = "FAILED"
+	BatchStatusRejected   = "REJECTED"
+
+	BatchItemStatusSuccess = "SUCCESS"
+	BatchItemStatusFailed  = "FAILED"
 )
 
 // Error codes
@@ -87,10 +99,12 @@ const (
 // ClientOption is a functional option for configuring the client
 type ClientOption func(*Client)
 
-// WithHTTPClient sets a custom HTTP client
+// WithHTTPClient sets a custom HTTP client. The caller keeps ownership of it;
+// Client.Close leaves its connections alone.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
 		c.httpClient = httpClient
+		c.sharedHTTPClient = true
 	}
 }
 
@@ -102,10 +116,11 @@ func WithRetryConfig(maxRetries int, retryDelay time.Duration) ClientOption {
 	}
 }
 
-// WithRateLimit sets rate limiting configuration
+// WithRateLimit sets rate limiting configuration. The bucket starts full, so
+// up to requestsPerMinute requests may be sent at once.
 func WithRateLimit(requestsPerMinute int) ClientOption {
 	return func(c *Client) {
-		c.rateLimiter = newRateLimiter(requestsPerMinute)
+		c.rateLimiter = NewRateLimiter(requestsPerMinute, requestsPerMinute)
 	}
 }
 
@@ -126,10 +141,19 @@ type Client struct {
 	httpClient  *http.Client
 	maxRetries  int
 	retryDelay  time.Duration
-	rateLimiter *rateLimiter
+	rateLimiter *RateLimiter
+
+	endpointLimits []endpointLimit
+
+	sharedHTTPClient bool // httpClient was passed in through WithHTTPClient
 
 	endpoints map[string]string // endpoint overrides
 
+	middlewares []Middleware
+	handler     RoundTripFunc
+	logger      *slog.Logger
+	retryPolicy RetryPolicy
+
 	// Service interfaces
 	Products          ProductService
 	Orders            OrderService
@@ -163,7 +187,7 @@ func NewClient(sellerID, apiKey, apiSecret string, isSandbox bool, opts ...Clien
 		},
 		maxRetries:  3,
 		retryDelay:  time.Second,
-		rateLimiter: newRateLimiter(60), // Default 60 requests per minute
+		rateLimiter: NewRateLimiter(60, 60), // Default 60 requests per minute
 	}
 
 	// Apply options
@@ -171,6 +195,22 @@ func NewClient(sellerID, apiKey, apiSecret string, isSandbox bool, opts ...Clien
 		opt(c)
 	}
 
+	if c.rateLimiter == nil {
+		c.rateLimiter = NewRateLimiter(60, 60)
+	}
+	for _, l := range c.endpointLimits {
+		c.rateLimiter.SetEndpointLimit(l.limit, l.keys...)
+	}
+	if c.retryPolicy == nil {
+		c.retryPolicy = &DefaultRetryPolicy{
+			MaxRetries: c.maxRetries,
+			BaseDelay:  c.retryDelay,
+			MaxDelay:   time.Minute,
+			Jitter:     0.2,
+		}
+	}
+	c.handler = chain(c.do, c.middlewares)
+
 	// Initialize services
 	c.Products = &productService{client: c}
 	c.Orders = &orderService{client: c}
@@ -188,61 +228,21 @@ func NewClient(sellerID, apiKey, apiSecret string, isSandbox bool, opts ...Clien
 	return c
 }
 
-// rateLimiter implements a simple token bucket rate limiter
-type rateLimiter struct {
-	tokens    int
-	maxTokens int
-	mu        sync.Mutex
-	ticker    *time.Ticker
-}
-
-func newRateLimiter(requestsPerMinute int) *rateLimiter {
-	rl := &rateLimiter{
-		tokens:    requestsPerMinute,
-		maxTokens: requestsPerMinute,
-		ticker:    time.NewTicker(time.Minute / time.Duration(requestsPerMinute)),
-	}
-
-	go func() {
-		for range rl.ticker.C {
-			rl.mu.Lock()
-			if rl.tokens < rl.maxTokens {
-				rl.tokens++
-			}
-			rl.mu.Unlock()
-		}
-	}()
-
-	return rl
-}
-
-func (rl *rateLimiter) Wait(ctx context.Context) error {
-	for {
-		rl.mu.Lock()
-		if rl.tokens > 0 {
-			rl.tokens--
-			rl.mu.Unlock()
-			return nil
-		}
-		rl.mu.Unlock()
-
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(100 * time.Millisecond):
-			// Check again
-		}
-	}
-}
-
 // Request represents an API request configuration
 type Request struct {
+	Endpoint    string // endpoint key, e.g. EndpointGetOrdersKey
 	Method      string
 	Path        string
 	Query       url.Values
+	Header      http.Header // extra headers sent with every attempt
+	Idempotent  bool        // allows retrying non-idempotent methods such as POST
 	Body        interface{}
 	Result      interface{}
 	RawResponse bool
+
+	// Populated from the last HTTP response
+	StatusCode     int
+	ResponseHeader http.Header
 }
 
 // Error represents a Trendyol API error
@@ -251,6 +251,12 @@ type Error struct {
 	Status     string      `json:"status,omitempty"`
 	Message    string      `json:"message,omitempty"`
 	Errors     []ErrorItem `json:"errors,omitempty"`
+
+	// Request context, filled in by the client
+	Endpoint  string `json:"-"` // endpoint key of the failed request
+	Method    string `json:"-"`
+	RequestID string `json:"-"` // request ID response header, if any
+	Body      []byte `json:"-"` // raw response body
 }
 
 // ErrorItem represents a single error in the errors array
@@ -275,51 +281,64 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("Trendyol API Error (%d): %s", e.StatusCode, e.Message)
 }
 
-// Do executes an API request with automatic retry and rate limiting
+// Do executes an API request through the middleware chain with automatic
+// retry and rate limiting
 func (c *Client) Do(ctx context.Context, req *Request) error {
-	// Rate limiting
-	if err := c.rateLimiter.Wait(ctx); err != nil {
-		return fmt.Errorf("rate limit wait failed: %w", err)
-	}
-
-	var lastErr error
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		if attempt > 0 {
-			// Exponential backoff
-			delay := c.retryDelay * time.Duration(1<<(attempt-1))
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(delay):
-			}
+	if c.handler != nil {
+		return c.handler(ctx, req)
+	}
+	return c.do(ctx, req)
+}
+
+// do is the innermost handler of the middleware chain
+func (c *Client) do(ctx context.Context, req *Request) error {
+	var attempts []error
+	for attempt := 1; ; attempt++ {
+		// Every attempt, retries included, is a request against the quota
+		if err := c.rateLimiter.Wait(ctx, req.Endpoint); err != nil {
+			return fmt.Errorf("rate limit wait failed: %w", err)
 		}
 
-		err := c.doRequest(ctx, req)
+		start := time.Now()
+		body, err := c.doRequest(ctx, req)
+		var delay time.Duration
+		retry := false
+		if err != nil && ctx.Err() == nil {
+			delay, retry = c.retryPolicy.Retry(req, attempt, err)
+		}
+		c.logAttempt(ctx, req, attempt, time.Since(start), delay, body, err)
 		if err == nil {
 			return nil
 		}
-
-		lastErr = err
-
-		// Check if error is retryable
-		if apiErr, ok := err.(*Error); ok {
-			// Don't retry client errors (4xx) except rate limit
-			if apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 && apiErr.StatusCode != 429 {
+		attempts = append(attempts, err)
+		if !retry {
+			if attempt == 1 {
 				return err
 			}
+			err = &RetryError{Attempts: attempts}
+			c.logGiveUp(ctx, req, attempt, err)
+			return err
 		}
-	}
 
-	return fmt.Errorf("request failed after %d attempts: %w", c.maxRetries+1, lastErr)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
 }
 
-func (c *Client) doRequest(ctx context.Context, req *Request) error {
+// doRequest performs a single HTTP attempt and returns the raw response body
+func (c *Client) doRequest(ctx context.Context, req *Request) ([]byte, error) {
 	// Build URL
 	u, err := url.Parse(c.baseURL)
 	if err != nil {
-		return fmt.Errorf("invalid base URL: %w", err)
+		return nil, fmt.Errorf("invalid base URL: %w", err)
 	}
 
+	req.StatusCode = 0
+	req.ResponseHeader = nil
+
 	// Use path as-is from resolve() - no hardcoded logic
 	path := req.Path
 	u.Path = strings.TrimSuffix(u.Path, "/") + path
@@ -334,7 +353,7 @@ func (c *Client) doRequest(ctx context.Context, req *Request) error {
 	if req.Body != nil {
 		bodyBytes, err := json.Marshal(req.Body)
 		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
@@ -342,7 +361,7 @@ func (c *Client) doRequest(ctx context.Context, req *Request) error {
 	// Create HTTP request
 	httpReq, err := http.NewRequestWithContext(ctx, req.Method, u.String(), bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
@@ -351,18 +370,23 @@ func (c *Client) doRequest(ctx context.Context, req *Request) error {
 	httpReq.Header.Set("User-Agent", c.userAgent)
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Accept", "application/json")
+	for k, v := range req.Header {
+		httpReq.Header[k] = v
+	}
 
 	// Execute request
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	req.StatusCode = resp.StatusCode
+	req.ResponseHeader = resp.Header
 
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	// Handle errors
@@ -375,14 +399,23 @@ func (c *Client) doRequest(ctx context.Context, req *Request) error {
 			// Fallback for non-standard error responses
 			apiErr.Message = string(body)
 		}
+		apiErr.Endpoint = req.Endpoint
+		apiErr.Method = req.Method
+		apiErr.Body = body
+		for _, h := range requestIDHeaders {
+			if id := resp.Header.Get(h); id != "" {
+				apiErr.RequestID = id
+				break
+			}
+		}
 
-		return &apiErr
+		return body, &apiErr
 	}
 
 	// Parse successful response
 	if req.Result != nil && !req.RawResponse {
 		if err := json.Unmarshal(body, req.Result); err != nil {
-			return fmt.Errorf("failed to unmarshal response: %w", err)
+			return body, fmt.Errorf("failed to unmarshal response: %w", err)
 		}
 	} else if req.RawResponse && req.Result != nil {
 		// For raw response, store the body as []byte
@@ -391,7 +424,7 @@ func (c *Client) doRequest(ctx context.Context, req *Request) error {
 		}
 	}
 
-	return nil
+	return body, nil
 }
 
 // Pagination represents pagination parameters
@@ -484,6 +517,12 @@ type UpdateProductsRequest struct {
 	Items []Product `json:"items"`
 }
 
+// ArchiveStateItem archives or unarchives a single product
+type ArchiveStateItem struct {
+	Barcode  string `json:"barcode"`
+	Archived bool   `json:"archived"`
+}
+
 // BatchResponse represents a batch operation response
 type BatchResponse struct {
 	BatchRequestID string `json:"batchRequestId"`
@@ -558,7 +597,7 @@ type Address struct {
 
 // UpdatePackageStatusRequest represents a package status update request
 type UpdatePackageStatusRequest struct {
-	Status string                    `json:"status"`
+	Status PackageStatus             `json:"status"`
 	Lines  []UpdatePackageStatusLine `json:"lines"`
 	Params map[string]string         `json:"params,omitempty"`
 }
@@ -608,21 +647,85 @@ type TrackingNumberRequest struct {
 
 // Claim represents a return/claim
 type Claim struct {
-	ID               int64       `json:"id"`
-	Status           string      `json:"status"`
-	CreatedDate      int64       `json:"createdDate"`
-	LastModifiedDate int64       `json:"lastModifiedDate"`
-	Items            []ClaimItem `json:"items"`
+	ID                     int64       `json:"id"`
+	Status                 string      `json:"status"`
+	CreatedDate            int64       `json:"createdDate"`
+	LastModifiedDate       int64       `json:"lastModifiedDate"`
+	ClaimDate              int64       `json:"claimDate,omitempty"`
+	OrderNumber            string      `json:"orderNumber"`
+	OrderDate              int64       `json:"orderDate,omitempty"`
+	OrderShipmentPackageID int64       `json:"orderShipmentPackageId,omitempty"` // package the items were shipped in
+	OrderOutboundPackageID int64       `json:"orderOutboundPackageId,omitempty"`
+	CustomerFirstName      string      `json:"customerFirstName"`
+	CustomerLastName       string      `json:"customerLastName"`
+	CargoTrackingNumber    int64       `json:"cargoTrackingNumber,omitempty"` // return shipment
+	CargoTrackingLink      string      `json:"cargoTrackingLink,omitempty"`
+	CargoSenderNumber      string      `json:"cargoSenderNumber,omitempty"`
+	CargoProviderName      string      `json:"cargoProviderName,omitempty"`
+	Items                  []ClaimItem `json:"items"`
+}
+
+// ClaimItem is a claimed order line together with its claimed units
+type ClaimItem struct {
+	OrderLine  ClaimOrderLine    `json:"orderLine"`
+	ClaimItems []ClaimItemDetail `json:"claimItems"`
+}
+
+// ClaimOrderLine is the order line a claim item was returned from
+type ClaimOrderLine struct {
+	ID              int64   `json:"id"`
+	ProductName     string  `json:"productName"`
+	Barcode         string  `json:"barcode"`
+	MerchantSKU     string  `json:"merchantSku,omitempty"`
+	ProductColor    string  `json:"productColor,omitempty"`
+	ProductSize     string  `json:"productSize,omitempty"`
+	Price           float64 `json:"price"`
+	VATBaseAmount   float64 `json:"vatBaseAmount,omitempty"`
+	VATRate         float64 `json:"vatRate,omitempty"`
+	SalesCampaignID int64   `json:"salesCampaignId,omitempty"`
+	ProductCategory string  `json:"productCategory,omitempty"`
+}
+
+// ClaimItemDetail is a single claimed unit of an order line. Its ID is the
+// one passed to Claims.ApproveItems and Claims.RejectItems.
+type ClaimItemDetail struct {
+	ID               int64            `json:"id"`
+	OrderLineItemID  int64            `json:"orderLineItemId,omitempty"`
+	Status           ClaimItemStatus  `json:"claimItemStatus"`
+	CustomerReason   *ClaimItemReason `json:"customerClaimItemReason,omitempty"`
+	TrendyolReason   *ClaimItemReason `json:"trendyolClaimItemReason,omitempty"`
+	CustomerNote     string           `json:"customerNote,omitempty"`
+	Note             string           `json:"note,omitempty"`
+	Resolved         bool             `json:"resolved"`
+	AutoAccepted     bool             `json:"autoAccepted"`
+	AcceptedBySeller bool             `json:"acceptedBySeller"`
+}
+
+// ClaimItemStatus is the status object of a claim item, e.g. {"name":"Accepted"}
+type ClaimItemStatus struct {
+	Name string `json:"name"`
 }
 
-// ClaimItem represents an item in a claim
-type ClaimItem struct {
-	ID         int64  `json:"id"`
-	Barcode    string `json:"barcode"`
-	Quantity   int    `json:"quantity"`
-	ReasonText string `json:"reasonText"`
+// ClaimItemReason is the return reason given by the customer or assigned by
+// Trendyol
+type ClaimItemReason struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	Code             string `json:"code,omitempty"`
+	ExternalReasonID int    `json:"externalReasonId,omitempty"`
 }
 
+// Claim item statuses
+const (
+	ClaimItemStatusCreated         = "Created"
+	ClaimItemStatusWaitingInAction = "WaitingInAction"
+	ClaimItemStatusAccepted        = "Accepted"
+	ClaimItemStatusRejected        = "Rejected"
+	ClaimItemStatusCancelled       = "Cancelled"
+	ClaimItemStatusUnresolved      = "Unresolved"
+	ClaimItemStatusInAnalysis      = "InAnalysis"
+)
+
 // ClaimReason represents a claim reason
 type ClaimReason struct {
 	ClaimIssueReasonID int    `json:"claimIssueReasonId"`
@@ -661,8 +764,9 @@ type Brand struct {
 func (c *Client) TestAuthentication(ctx context.Context) error {
 	// Use products endpoint to test authentication since it's more reliable
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   c.resolve(EndpointGetProductsKey, c.sellerID),
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetProductsKey,
+		Path:     c.resolve(EndpointGetProductsKey, c.sellerID),
 		Query: url.Values{
 			"size": []string{"1"},
 		},
@@ -675,8 +779,9 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 
 	// Since there's no dedicated health endpoint, we use a simple products query
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   c.resolve(EndpointGetProductsKey, c.sellerID),
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetProductsKey,
+		Path:     c.resolve(EndpointGetProductsKey, c.sellerID),
 		Query: url.Values{
 			"size": []string{"1"},
 			"page": []string{"0"},
@@ -690,10 +795,24 @@ type ProductService interface {
 	Create(ctx context.Context, products []Product) (*BatchResponse, error)
 	Update(ctx context.Context, products []Product) (*BatchResponse, error)
 	Delete(ctx context.Context, barcodes []string) (*BatchResponse, error)
+	// UpdateArchiveState archives or unarchives products by barcode
+	UpdateArchiveState(ctx context.Context, items []ArchiveStateItem) (*BatchResponse, error)
 	GetBatchStatus(ctx context.Context, batchRequestID string) (*BatchStatusResponse, error)
+	// WaitForBatch polls GetBatchStatus until the batch completes and decodes its items.
+	// A failed or rejected batch is returned together with an error.
+	WaitForBatch(ctx context.Context, batchRequestID string, opts *WaitOptions) (*BatchResult, error)
+	// CreateChunked and UpdateChunked split products into API-sized batches
+	CreateChunked(ctx context.Context, products []Product, opts *ChunkOptions) (*ChunkedResult, error)
+	UpdateChunked(ctx context.Context, products []Product, opts *ChunkOptions) (*ChunkedResult, error)
+	UpdateArchiveStateChunked(ctx context.Context, items []ArchiveStateItem, opts *ChunkOptions) (*ChunkedResult, error)
 	List(ctx context.Context, page, size int) ([]Product, *PaginatedResponse, error)
 	ListWithOptions(ctx context.Context, page, size int, opts *ProductListOptions) ([]Product, *PaginatedResponse, error)
+	// GetByBarcode returns a *ProductNotFoundError, matching ErrNotFound, when no product has barcode
 	GetByBarcode(ctx context.Context, barcode string) (*Product, error)
+	// GetByBarcodes looks up many barcodes with bounded concurrency and returns the missing ones
+	GetByBarcodes(ctx context.Context, barcodes []string) (map[string]Product, []string, error)
+	// ForEach calls fn for every product matching opts, walking all pages
+	ForEach(ctx context.Context, size int, opts *ProductListOptions, fn func(Product) error) error
 }
 
 // OrderService defines operations for order management
@@ -701,6 +820,10 @@ type OrderService interface {
 	List(ctx context.Context, opts ListOrdersOptions) ([]Order, *PaginatedResponse, error)
 	ListLegacy(ctx context.Context, opts ListOrdersOptions) ([]ShipmentPackage, *PaginatedResponse, error)
 	UpdateStatus(ctx context.Context, packageID int64, req UpdatePackageStatusRequest) error
+	// MarkPicking moves order to Picking with all of its lines, rejecting illegal transitions locally
+	MarkPicking(ctx context.Context, order Order) error
+	// MarkInvoiced moves order to Invoiced with all of its lines, rejecting illegal transitions locally
+	MarkInvoiced(ctx context.Context, order Order, invoiceNumber string) error
 	UpdateTrackingNumber(ctx context.Context, packageID int64, trackingNumber string) error
 	SendInvoiceLink(ctx context.Context, packageID int64, invoiceLink string) error
 	// New methods
@@ -717,11 +840,15 @@ type OrderService interface {
 	ExtendDeliveryDate(ctx context.Context, packageID int64, extendedDayCount int) error
 	UpdateLaborCosts(ctx context.Context, packageID int64, costs []LaborCost) error
 	DeliveredByService(ctx context.Context, packageID int64) error
+	// ForEach calls fn for every order matching opts, starting at opts.Page
+	ForEach(ctx context.Context, opts ListOrdersOptions, fn func(Order) error) error
 }
 
 // PriceInventoryService defines operations for price and inventory management
 type PriceInventoryService interface {
 	Update(ctx context.Context, items []PriceInventoryItem) (*BatchResponse, error)
+	// UpdateChunked splits items into batches of at most MaxPriceInventoryBatchSize
+	UpdateChunked(ctx context.Context, items []PriceInventoryItem, opts *ChunkOptions) (*ChunkedResult, error)
 	DeleteProduct(ctx context.Context, barcode string) error
 	DeleteProducts(ctx context.Context, barcodes []string) error
 	ApplyPriceIncrease(ctx context.Context, items []PriceInventoryItem, percentage float64) (*BatchResponse, error)
@@ -731,9 +858,14 @@ type PriceInventoryService interface {
 // ClaimService defines operations for claim/return management
 type ClaimService interface {
 	List(ctx context.Context, status string, page, size int) ([]Claim, *PaginatedResponse, error)
+	ListWithOptions(ctx context.Context, opts ListClaimsOptions) ([]Claim, *PaginatedResponse, error)
 	GetReasons(ctx context.Context) ([]ClaimReason, error)
 	ApproveItems(ctx context.Context, claimID int64, itemIDs []int64) error
 	RejectItems(ctx context.Context, claimID int64, reasonID int, itemIDs []int64, description string) error
+	// ForEach calls fn for every claim with the given item status, walking all pages
+	ForEach(ctx context.Context, status string, size int, fn func(Claim) error) error
+	// ForEachWithOptions calls fn for every claim matching opts, starting at opts.Page
+	ForEachWithOptions(ctx context.Context, opts ListClaimsOptions, fn func(Claim) error) error
 }
 
 // AddressService defines operations for address management
@@ -746,11 +878,13 @@ type CategoryService interface {
 	ListCategories(ctx context.Context) ([]Category, error)
 	GetCategoryAttributes(ctx context.Context, categoryID int) ([]CategoryAttribute, error)
 	ListBrands(ctx context.Context, page, size int) ([]Brand, *PaginatedResponse, error)
+	// ForEachBrand calls fn for every brand until an empty page is returned
+	ForEachBrand(ctx context.Context, size int, fn func(Brand) error) error
 }
 
 // ListOrdersOptions represents options for listing orders
 type ListOrdersOptions struct {
-	Status           string
+	Status           PackageStatus
 	StartDate        *time.Time
 	EndDate          *time.Time
 	OrderByField     string
@@ -763,6 +897,17 @@ type ListOrdersOptions struct {
 	ShipmentPackageIDs []int64 // Birden fazla paket ID'si ile arama
 }
 
+// ListClaimsOptions filters Claims.ListWithOptions
+type ListClaimsOptions struct {
+	ClaimItemStatus string
+	StartDate       *time.Time
+	EndDate         *time.Time
+	ClaimIDs        []int64
+	OrderNumber     string
+	Page            int
+	Size            int
+}
+
 type ProductListOptions struct {
 	Approved      *bool      `json:"approved,omitempty"`
 	Archived      *bool      `json:"archived,omitempty"`
@@ -779,6 +924,12 @@ type ProductListOptions struct {
 	BrandIDs      []int      `json:"brandIds,omitempty"`
 }
 
+// ProductListOptions.DateQueryType values
+const (
+	DateQueryCreated      = "CREATED_DATE"
+	DateQueryLastModified = "LAST_MODIFIED_DATE"
+)
+
 // productService implements ProductService
 type productService struct {
 	client *Client
@@ -786,10 +937,11 @@ type productService struct {
 
 func (s *productService) Create(ctx context.Context, products []Product) (*BatchResponse, error) {
 	req := &Request{
-		Method: http.MethodPost,
-		Path:   s.client.resolve(EndpointCreateProductsKey, s.client.sellerID),
-		Body:   CreateProductsRequest{Items: products},
-		Result: &BatchResponse{},
+		Method:   http.MethodPost,
+		Endpoint: EndpointCreateProductsKey,
+		Path:     s.client.resolve(EndpointCreateProductsKey, s.client.sellerID),
+		Body:     CreateProductsRequest{Items: products},
+		Result:   &BatchResponse{},
 	}
 	err := s.client.Do(ctx, req)
 	if err != nil {
@@ -800,10 +952,26 @@ func (s *productService) Create(ctx context.Context, products []Product) (*Batch
 
 func (s *productService) Update(ctx context.Context, products []Product) (*BatchResponse, error) {
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointUpdateProductsKey, s.client.sellerID),
-		Body:   UpdateProductsRequest{Items: products},
-		Result: &BatchResponse{},
+		Method:   http.MethodPut,
+		Endpoint: EndpointUpdateProductsKey,
+		Path:     s.client.resolve(EndpointUpdateProductsKey, s.client.sellerID),
+		Body:     UpdateProductsRequest{Items: products},
+		Result:   &BatchResponse{},
+	}
+	err := s.client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return req.Result.(*BatchResponse), nil
+}
+
+func (s *productService) UpdateArchiveState(ctx context.Context, items []ArchiveStateItem) (*BatchResponse, error) {
+	req := &Request{
+		Method:   http.MethodPut,
+		Endpoint: EndpointUpdateArchiveStateKey,
+		Path:     s.client.resolve(EndpointUpdateArchiveStateKey, s.client.sellerID),
+		Body:     map[string]interface{}{"items": items},
+		Result:   &BatchResponse{},
 	}
 	err := s.client.Do(ctx, req)
 	if err != nil {
@@ -827,10 +995,11 @@ func (s *productService) Delete(ctx context.Context, barcodes []string) (*BatchR
 	}
 
 	req := &Request{
-		Method: http.MethodDelete,
-		Path:   s.client.resolve(EndpointDeleteProductsKey, s.client.sellerID),
-		Body:   body,
-		Result: &BatchResponse{},
+		Method:   http.MethodDelete,
+		Endpoint: EndpointDeleteProductsKey,
+		Path:     s.client.resolve(EndpointDeleteProductsKey, s.client.sellerID),
+		Body:     body,
+		Result:   &BatchResponse{},
 	}
 	err := s.client.Do(ctx, req)
 	if err != nil {
@@ -844,6 +1013,7 @@ func (s *productService) GetBatchStatus(ctx context.Context, batchRequestID stri
 	var rawResp []byte
 	req := &Request{
 		Method:      http.MethodGet,
+		Endpoint:    EndpointGetBatchRequestResultKey,
 		Path:        s.client.resolve(EndpointGetBatchRequestResultKey, s.client.sellerID, batchRequestID),
 		Result:      &rawResp,
 		RawResponse: true,
@@ -936,10 +1106,11 @@ func (s *productService) ListWithOptions(ctx context.Context, page, size int, op
 
 	result := &response{}
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetProductsKey, s.client.sellerID),
-		Query:  query,
-		Result: result,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetProductsKey,
+		Path:     s.client.resolve(EndpointGetProductsKey, s.client.sellerID),
+		Query:    query,
+		Result:   result,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -950,33 +1121,6 @@ func (s *productService) ListWithOptions(ctx context.Context, page, size int, op
 	return result.Content, &result.PaginatedResponse, nil
 }
 
-func (s *productService) GetByBarcode(ctx context.Context, barcode string) (*Product, error) {
-	type response struct {
-		Content []Product `json:"content"`
-	}
-
-	result := &response{}
-	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetProductsKey, s.client.sellerID),
-		Query: url.Values{
-			"barcode": []string{barcode},
-		},
-		Result: result,
-	}
-
-	err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(result.Content) == 0 {
-		return nil, fmt.Errorf("product not found with barcode: %s", barcode)
-	}
-
-	return &result.Content[0], nil
-}
-
 // orderService implements OrderService
 type orderService struct {
 	client *Client
@@ -994,7 +1138,7 @@ func (s *orderService) List(ctx context.Context, opts ListOrdersOptions) ([]Orde
 	}
 
 	if opts.Status != "" {
-		query.Set("status", opts.Status)
+		query.Set("status", string(opts.Status))
 	}
 	if opts.StartDate != nil {
 		query.Set("startDate", strconv.FormatInt(opts.StartDate.UnixMilli(), 10))
@@ -1021,10 +1165,11 @@ func (s *orderService) List(ctx context.Context, opts ListOrdersOptions) ([]Orde
 
 	result := &response{}
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetOrdersKey, s.client.sellerID),
-		Query:  query,
-		Result: result,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetOrdersKey,
+		Path:     s.client.resolve(EndpointGetOrdersKey, s.client.sellerID),
+		Query:    query,
+		Result:   result,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1047,7 +1192,7 @@ func (s *orderService) ListLegacy(ctx context.Context, opts ListOrdersOptions) (
 	}
 
 	if opts.Status != "" {
-		query.Set("status", opts.Status)
+		query.Set("status", string(opts.Status))
 	}
 	if opts.StartDate != nil {
 		query.Set("startDate", strconv.FormatInt(opts.StartDate.UnixMilli(), 10))
@@ -1074,10 +1219,11 @@ func (s *orderService) ListLegacy(ctx context.Context, opts ListOrdersOptions) (
 
 	result := &response{}
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetOrdersKey, s.client.sellerID),
-		Query:  query,
-		Result: result,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetOrdersKey,
+		Path:     s.client.resolve(EndpointGetOrdersKey, s.client.sellerID),
+		Query:    query,
+		Result:   result,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1090,27 +1236,30 @@ func (s *orderService) ListLegacy(ctx context.Context, opts ListOrdersOptions) (
 
 func (s *orderService) UpdateStatus(ctx context.Context, packageID int64, req UpdatePackageStatusRequest) error {
 	request := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointUpdatePackageStatusKey, s.client.sellerID, packageID),
-		Body:   req,
+		Method:   http.MethodPut,
+		Endpoint: EndpointUpdatePackageStatusKey,
+		Path:     s.client.resolve(EndpointUpdatePackageStatusKey, s.client.sellerID, packageID),
+		Body:     req,
 	}
 	return s.client.Do(ctx, request)
 }
 
 func (s *orderService) UpdateTrackingNumber(ctx context.Context, packageID int64, trackingNumber string) error {
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointUpdateTrackingNumberKey, s.client.sellerID, packageID),
-		Body:   TrackingNumberRequest{TrackingNumber: trackingNumber},
+		Method:   http.MethodPut,
+		Endpoint: EndpointUpdateTrackingNumberKey,
+		Path:     s.client.resolve(EndpointUpdateTrackingNumberKey, s.client.sellerID, packageID),
+		Body:     TrackingNumberRequest{TrackingNumber: trackingNumber},
 	}
 	return s.client.Do(ctx, req)
 }
 
 func (s *orderService) SendInvoiceLink(ctx context.Context, packageID int64, invoiceLink string) error {
 	req := &Request{
-		Method: http.MethodPost,
-		Path:   s.client.resolve(EndpointSendInvoiceLinkKey, s.client.sellerID),
-		Body:   InvoiceLinkRequest{ShipmentPackageID: packageID, InvoiceLink: invoiceLink},
+		Method:   http.MethodPost,
+		Endpoint: EndpointSendInvoiceLinkKey,
+		Path:     s.client.resolve(EndpointSendInvoiceLinkKey, s.client.sellerID),
+		Body:     InvoiceLinkRequest{ShipmentPackageID: packageID, InvoiceLink: invoiceLink},
 	}
 	return s.client.Do(ctx, req)
 }
@@ -1122,9 +1271,10 @@ func (s *orderService) CancelPackageItems(ctx context.Context, packageID int64,
 	}
 
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointCancelPackageItemsKey, s.client.sellerID, packageID),
-		Body:   body,
+		Method:   http.MethodPut,
+		Endpoint: EndpointCancelPackageItemsKey,
+		Path:     s.client.resolve(EndpointCancelPackageItemsKey, s.client.sellerID, packageID),
+		Body:     body,
 	}
 	return s.client.Do(ctx, req)
 }
@@ -1135,9 +1285,10 @@ func (s *orderService) SplitPackage(ctx context.Context, packageID int64, orderL
 	}
 
 	req := &Request{
-		Method: http.MethodPost,
-		Path:   s.client.resolve(EndpointSplitPackageKey, s.client.sellerID, packageID),
-		Body:   body,
+		Method:   http.MethodPost,
+		Endpoint: EndpointSplitPackageKey,
+		Path:     s.client.resolve(EndpointSplitPackageKey, s.client.sellerID, packageID),
+		Body:     body,
 	}
 	return s.client.Do(ctx, req)
 }
@@ -1148,9 +1299,10 @@ func (s *orderService) MultiSplitPackage(ctx context.Context, packageID int64, s
 	}
 
 	req := &Request{
-		Method: http.MethodPost,
-		Path:   s.client.resolve(EndpointMultiSplitPackageKey, s.client.sellerID, packageID),
-		Body:   body,
+		Method:   http.MethodPost,
+		Endpoint: EndpointMultiSplitPackageKey,
+		Path:     s.client.resolve(EndpointMultiSplitPackageKey, s.client.sellerID, packageID),
+		Body:     body,
 	}
 	return s.client.Do(ctx, req)
 }
@@ -1161,9 +1313,10 @@ func (s *orderService) QuantitySplitPackage(ctx context.Context, packageID int64
 	}
 
 	req := &Request{
-		Method: http.MethodPost,
-		Path:   s.client.resolve(EndpointQuantitySplitPackageKey, s.client.sellerID, packageID),
-		Body:   body,
+		Method:   http.MethodPost,
+		Endpoint: EndpointQuantitySplitPackageKey,
+		Path:     s.client.resolve(EndpointQuantitySplitPackageKey, s.client.sellerID, packageID),
+		Body:     body,
 	}
 	return s.client.Do(ctx, req)
 }
@@ -1175,34 +1328,38 @@ func (s *orderService) UpdateBoxInfo(ctx context.Context, packageID int64, boxQu
 	}
 
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointUpdateBoxInfoKey, s.client.sellerID, packageID),
-		Body:   body,
+		Method:   http.MethodPut,
+		Endpoint: EndpointUpdateBoxInfoKey,
+		Path:     s.client.resolve(EndpointUpdateBoxInfoKey, s.client.sellerID, packageID),
+		Body:     body,
 	}
 	return s.client.Do(ctx, req)
 }
 
 func (s *orderService) AlternativeDelivery(ctx context.Context, packageID int64, req AlternativeDeliveryRequest) error {
 	request := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointAlternativeDeliveryKey, s.client.sellerID, packageID),
-		Body:   req,
+		Method:   http.MethodPut,
+		Endpoint: EndpointAlternativeDeliveryKey,
+		Path:     s.client.resolve(EndpointAlternativeDeliveryKey, s.client.sellerID, packageID),
+		Body:     req,
 	}
 	return s.client.Do(ctx, request)
 }
 
 func (s *orderService) ManualDeliver(ctx context.Context, cargoTrackingNumber string) error {
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointManualDeliverKey, s.client.sellerID, cargoTrackingNumber),
+		Method:   http.MethodPut,
+		Endpoint: EndpointManualDeliverKey,
+		Path:     s.client.resolve(EndpointManualDeliverKey, s.client.sellerID, cargoTrackingNumber),
 	}
 	return s.client.Do(ctx, req)
 }
 
 func (s *orderService) ManualReturn(ctx context.Context, cargoTrackingNumber string) error {
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointManualReturnKey, s.client.sellerID, cargoTrackingNumber),
+		Method:   http.MethodPut,
+		Endpoint: EndpointManualReturnKey,
+		Path:     s.client.resolve(EndpointManualReturnKey, s.client.sellerID, cargoTrackingNumber),
 	}
 	return s.client.Do(ctx, req)
 }
@@ -1213,9 +1370,10 @@ func (s *orderService) UpdateCargoProvider(ctx context.Context, packageID int64,
 	}
 
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointUpdateCargoProviderKey, s.client.sellerID, packageID),
-		Body:   body,
+		Method:   http.MethodPut,
+		Endpoint: EndpointUpdateCargoProviderKey,
+		Path:     s.client.resolve(EndpointUpdateCargoProviderKey, s.client.sellerID, packageID),
+		Body:     body,
 	}
 	return s.client.Do(ctx, req)
 }
@@ -1226,9 +1384,10 @@ func (s *orderService) UpdateWarehouse(ctx context.Context, packageID int64, war
 	}
 
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointUpdateWarehouseKey, s.client.sellerID, packageID),
-		Body:   body,
+		Method:   http.MethodPut,
+		Endpoint: EndpointUpdateWarehouseKey,
+		Path:     s.client.resolve(EndpointUpdateWarehouseKey, s.client.sellerID, packageID),
+		Body:     body,
 	}
 	return s.client.Do(ctx, req)
 }
@@ -1239,26 +1398,29 @@ func (s *orderService) ExtendDeliveryDate(ctx context.Context, packageID int64,
 	}
 
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointExtendDeliveryDateKey, s.client.sellerID, packageID),
-		Body:   body,
+		Method:   http.MethodPut,
+		Endpoint: EndpointExtendDeliveryDateKey,
+		Path:     s.client.resolve(EndpointExtendDeliveryDateKey, s.client.sellerID, packageID),
+		Body:     body,
 	}
 	return s.client.Do(ctx, req)
 }
 
 func (s *orderService) UpdateLaborCosts(ctx context.Context, packageID int64, costs []LaborCost) error {
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointUpdateLaborCostsKey, s.client.sellerID, packageID),
-		Body:   costs,
+		Method:   http.MethodPut,
+		Endpoint: EndpointUpdateLaborCostsKey,
+		Path:     s.client.resolve(EndpointUpdateLaborCostsKey, s.client.sellerID, packageID),
+		Body:     costs,
 	}
 	return s.client.Do(ctx, req)
 }
 
 func (s *orderService) DeliveredByService(ctx context.Context, packageID int64) error {
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointDeliveredByServiceKey, s.client.sellerID, packageID),
+		Method:   http.MethodPut,
+		Endpoint: EndpointDeliveredByServiceKey,
+		Path:     s.client.resolve(EndpointDeliveredByServiceKey, s.client.sellerID, packageID),
 	}
 	return s.client.Do(ctx, req)
 }
@@ -1270,10 +1432,13 @@ type priceInventoryService struct {
 
 func (s *priceInventoryService) Update(ctx context.Context, items []PriceInventoryItem) (*BatchResponse, error) {
 	req := &Request{
-		Method: http.MethodPost,
-		Path:   s.client.resolve(EndpointUpdatePriceInventoryKey, s.client.sellerID),
-		Body:   map[string]interface{}{"items": items},
-		Result: &BatchResponse{},
+		Method:   http.MethodPost,
+		Endpoint: EndpointUpdatePriceInventoryKey,
+		Path:     s.client.resolve(EndpointUpdatePriceInventoryKey, s.client.sellerID),
+		Body:     map[string]interface{}{"items": items},
+		Result:   &BatchResponse{},
+		// Absolute stock and price values, resending is harmless
+		Idempotent: true,
 	}
 	err := s.client.Do(ctx, req)
 	if err != nil {
@@ -1337,25 +1502,43 @@ type claimService struct {
 }
 
 func (s *claimService) List(ctx context.Context, status string, page, size int) ([]Claim, *PaginatedResponse, error) {
+	return s.ListWithOptions(ctx, ListClaimsOptions{ClaimItemStatus: status, Page: page, Size: size})
+}
+
+// ListWithOptions returns one page of claims matching opts
+func (s *claimService) ListWithOptions(ctx context.Context, opts ListClaimsOptions) ([]Claim, *PaginatedResponse, error) {
 	type response struct {
 		Content []Claim `json:"content"`
 		PaginatedResponse
 	}
 
 	query := url.Values{
-		"page": []string{strconv.Itoa(page)},
-		"size": []string{strconv.Itoa(size)},
+		"page": []string{strconv.Itoa(opts.Page)},
+		"size": []string{strconv.Itoa(opts.Size)},
 	}
-	if status != "" {
-		query.Set("claimItemStatus", status)
+	if opts.ClaimItemStatus != "" {
+		query.Set("claimItemStatus", opts.ClaimItemStatus)
+	}
+	if opts.StartDate != nil {
+		query.Set("startDate", strconv.FormatInt(opts.StartDate.UnixMilli(), 10))
+	}
+	if opts.EndDate != nil {
+		query.Set("endDate", strconv.FormatInt(opts.EndDate.UnixMilli(), 10))
+	}
+	if opts.OrderNumber != "" {
+		query.Set("orderNumber", opts.OrderNumber)
+	}
+	for _, id := range opts.ClaimIDs {
+		query.Add("claimIds", strconv.FormatInt(id, 10))
 	}
 
 	result := &response{}
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetClaimsKey, s.client.sellerID),
-		Query:  query,
-		Result: result,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetClaimsKey,
+		Path:     s.client.resolve(EndpointGetClaimsKey, s.client.sellerID),
+		Query:    query,
+		Result:   result,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1369,9 +1552,10 @@ func (s *claimService) List(ctx context.Context, status string, page, size int)
 func (s *claimService) GetReasons(ctx context.Context) ([]ClaimReason, error) {
 	var reasons []ClaimReason
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetClaimIssueReasonsKey),
-		Result: &reasons,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetClaimIssueReasonsKey,
+		Path:     s.client.resolve(EndpointGetClaimIssueReasonsKey),
+		Result:   &reasons,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1389,9 +1573,10 @@ func (s *claimService) ApproveItems(ctx context.Context, claimID int64, itemIDs
 	}
 
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointApproveClaimKey, s.client.sellerID, strconv.FormatInt(claimID, 10)),
-		Body:   body,
+		Method:   http.MethodPut,
+		Endpoint: EndpointApproveClaimKey,
+		Path:     s.client.resolve(EndpointApproveClaimKey, s.client.sellerID, strconv.FormatInt(claimID, 10)),
+		Body:     body,
 	}
 
 	return s.client.Do(ctx, req)
@@ -1414,9 +1599,10 @@ func (s *claimService) RejectItems(ctx context.Context, claimID int64, reasonID
 	}
 
 	req := &Request{
-		Method: http.MethodPost,
-		Path:   s.client.resolve(EndpointRejectClaimKey, s.client.sellerID, strconv.FormatInt(claimID, 10)),
-		Query:  query,
+		Method:   http.MethodPost,
+		Endpoint: EndpointRejectClaimKey,
+		Path:     s.client.resolve(EndpointRejectClaimKey, s.client.sellerID, strconv.FormatInt(claimID, 10)),
+		Query:    query,
 	}
 
 	return s.client.Do(ctx, req)
@@ -1439,9 +1625,10 @@ func (s *addressService) List(ctx context.Context) ([]Address, error) {
 
 	result := &response{}
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointSellerAddressesKey, s.client.sellerID),
-		Result: result,
+		Method:   http.MethodGet,
+		Endpoint: EndpointSellerAddressesKey,
+		Path:     s.client.resolve(EndpointSellerAddressesKey, s.client.sellerID),
+		Result:   result,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1464,9 +1651,10 @@ func (s *categoryService) ListCategories(ctx context.Context) ([]Category, error
 
 	result := &response{}
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetCategoriesKey),
-		Result: result,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetCategoriesKey,
+		Path:     s.client.resolve(EndpointGetCategoriesKey),
+		Result:   result,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1501,9 +1689,10 @@ func (s *categoryService) GetCategoryAttributes(ctx context.Context, categoryID
 
 	var response attrResponse
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetCategoryAttributesKey, categoryID),
-		Result: &response,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetCategoryAttributesKey,
+		Path:     s.client.resolve(EndpointGetCategoryAttributesKey, categoryID),
+		Result:   &response,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1543,8 +1732,9 @@ func (s *categoryService) ListBrands(ctx context.Context, page, size int) ([]Bra
 
 	result := &response{}
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetBrandsKey),
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetBrandsKey,
+		Path:     s.client.resolve(EndpointGetBrandsKey),
 		Query: url.Values{
 			"page": []string{strconv.Itoa(page)},
 			"size": []string{strconv.Itoa(size)},
@@ -1610,9 +1800,10 @@ type shipmentProviderService struct {
 func (s *shipmentProviderService) List(ctx context.Context) ([]ShipmentProvider, error) {
 	var providers []ShipmentProvider
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetShipmentProvidersKey),
-		Result: &providers,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetShipmentProvidersKey,
+		Path:     s.client.resolve(EndpointGetShipmentProvidersKey),
+		Result:   &providers,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1643,10 +1834,12 @@ func (c *Client) WithContext(timeout time.Duration) (context.Context, context.Ca
 	return context.WithTimeout(context.Background(), timeout)
 }
 
-// Close stops the rate limiter
+// Close releases idle connections of the HTTP client created by NewClient. A
+// client passed through WithHTTPClient may be shared and is left untouched.
+// The rate limiter runs no background goroutine and needs no cleanup.
 func (c *Client) Close() {
-	if c.rateLimiter != nil && c.rateLimiter.ticker != nil {
-		c.rateLimiter.ticker.Stop()
+	if !c.sharedHTTPClient {
+		c.httpClient.CloseIdleConnections()
 	}
 }
 
@@ -1654,27 +1847,45 @@ func (c *Client) Close() {
 type FinanceService interface {
 	GetSettlements(ctx context.Context, startDate, endDate time.Time, page, size int) ([]Settlement, *PaginatedResponse, error)
 	GetCargoInvoiceDetails(ctx context.Context, invoiceSerialNumber string) ([]CargoInvoiceDetail, error)
+	// ForEachSettlement calls fn for every settlement in the range, walking all pages
+	ForEachSettlement(ctx context.Context, startDate, endDate time.Time, size int, fn func(Settlement) error) error
+	// ForEachSettlementInRange walks a range of any length, split into
+	// windows the endpoint accepts, optionally filtered by transaction type
+	ForEachSettlementInRange(ctx context.Context, q SettlementQuery, fn func(Settlement) error) error
+	ListSettlementsInRange(ctx context.Context, q SettlementQuery) ([]Settlement, error)
+	// GetOtherFinancials returns one page of payment orders, deduction and
+	// commission invoices and other transactions not tied to an order line
+	GetOtherFinancials(ctx context.Context, startDate, endDate time.Time, transactionType string, page, size int) ([]OtherFinancial, *PaginatedResponse, error)
+	ForEachOtherFinancial(ctx context.Context, q OtherFinancialQuery, fn func(OtherFinancial) error) error
+	ListOtherFinancials(ctx context.Context, q OtherFinancialQuery) ([]OtherFinancial, error)
 }
 
 // Settlement represents a financial settlement record
 type Settlement struct {
+	ID                  string  `json:"id,omitempty"`
 	SettlementDate      int64   `json:"settlementDate"`
 	PaymentDate         int64   `json:"paymentDate"`
 	TransactionType     string  `json:"transactionType"`
 	OrderNumber         string  `json:"orderNumber"`
+	ShipmentPackageID   int64   `json:"shipmentPackageId,omitempty"`
+	Barcode             string  `json:"barcode,omitempty"`
 	Description         string  `json:"description"`
 	Amount              float64 `json:"amount"`
+	CommissionRate      float64 `json:"commissionRate,omitempty"`
 	CommissionAmount    float64 `json:"commissionAmount"`
 	SellerRevenue       float64 `json:"sellerRevenue"`
 	InvoiceSerialNumber string  `json:"invoiceSerialNumber,omitempty"`
+	PaymentOrderID      int64   `json:"paymentOrderId,omitempty"`
 }
 
 // CargoInvoiceDetail represents cargo invoice detail
 type CargoInvoiceDetail struct {
-	OrderNumber       string  `json:"orderNumber"`
-	ShipmentPackageID int64   `json:"shipmentPackageId"`
-	CargoAmount       float64 `json:"cargoAmount"`
-	CargoProviderName string  `json:"cargoProviderName"`
+	OrderNumber         string  `json:"orderNumber"`
+	ShipmentPackageID   int64   `json:"shipmentPackageId"`
+	CargoAmount         float64 `json:"cargoAmount"`
+	CargoProviderName   string  `json:"cargoProviderName"`
+	Deci                float64 `json:"desi,omitempty"`                // deci the package was billed at
+	ShipmentPackageType string  `json:"shipmentPackageType,omitempty"` // e.g. shipment or return
 }
 
 // CommonLabelService provides common label/barcode operations
@@ -1780,38 +1991,16 @@ type financeService struct {
 }
 
 func (s *financeService) GetSettlements(ctx context.Context, startDate, endDate time.Time, page, size int) ([]Settlement, *PaginatedResponse, error) {
-	type response struct {
-		Content []Settlement `json:"content"`
-		PaginatedResponse
-	}
-
-	result := &response{}
-	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetSettlementsKey, s.client.sellerID),
-		Query: url.Values{
-			"startDate": []string{strconv.FormatInt(startDate.UnixMilli(), 10)},
-			"endDate":   []string{strconv.FormatInt(endDate.UnixMilli(), 10)},
-			"page":      []string{strconv.Itoa(page)},
-			"size":      []string{strconv.Itoa(size)},
-		},
-		Result: result,
-	}
-
-	err := s.client.Do(ctx, req)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return result.Content, &result.PaginatedResponse, nil
+	return s.getSettlements(ctx, financeValues(startDate, endDate, "", 0, page, size))
 }
 
 func (s *financeService) GetCargoInvoiceDetails(ctx context.Context, invoiceSerialNumber string) ([]CargoInvoiceDetail, error) {
 	var details []CargoInvoiceDetail
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetCargoInvoiceDetailsKey, s.client.sellerID, invoiceSerialNumber),
-		Result: &details,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetCargoInvoiceDetailsKey,
+		Path:     s.client.resolve(EndpointGetCargoInvoiceDetailsKey, s.client.sellerID, invoiceSerialNumber),
+		Result:   &details,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1829,9 +2018,10 @@ type commonLabelService struct {
 
 func (s *commonLabelService) CreateLabel(ctx context.Context, cargoTrackingNumber string, req CommonLabelRequest) error {
 	request := &Request{
-		Method: http.MethodPost,
-		Path:   s.client.resolve(EndpointCreateCommonLabelKey, s.client.sellerID, cargoTrackingNumber),
-		Body:   req,
+		Method:   http.MethodPost,
+		Endpoint: EndpointCreateCommonLabelKey,
+		Path:     s.client.resolve(EndpointCreateCommonLabelKey, s.client.sellerID, cargoTrackingNumber),
+		Body:     req,
 	}
 
 	return s.client.Do(ctx, request)
@@ -1841,6 +2031,7 @@ func (s *commonLabelService) GetLabel(ctx context.Context, cargoTrackingNumber s
 	var result []byte
 	req := &Request{
 		Method:      http.MethodGet,
+		Endpoint:    EndpointGetCommonLabelKey,
 		Path:        s.client.resolve(EndpointGetCommonLabelKey, s.client.sellerID, cargoTrackingNumber),
 		Result:      &result,
 		RawResponse: true,
@@ -1862,9 +2053,10 @@ type memberService struct {
 func (s *memberService) GetCountries(ctx context.Context) ([]Country, error) {
 	var countries []Country
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetCountriesKey),
-		Result: &countries,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetCountriesKey,
+		Path:     s.client.resolve(EndpointGetCountriesKey),
+		Result:   &countries,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1878,9 +2070,10 @@ func (s *memberService) GetCountries(ctx context.Context) ([]Country, error) {
 func (s *memberService) GetCountryCities(ctx context.Context, countryCode string) ([]City, error) {
 	var cities []City
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetCountryCitiesKey, countryCode),
-		Result: &cities,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetCountryCitiesKey,
+		Path:     s.client.resolve(EndpointGetCountryCitiesKey, countryCode),
+		Result:   &cities,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1894,9 +2087,10 @@ func (s *memberService) GetCountryCities(ctx context.Context, countryCode string
 func (s *memberService) GetDomesticCities(ctx context.Context, countryCode string) ([]City, error) {
 	var cities []City
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointGetDomesticCitiesKey, countryCode),
-		Result: &cities,
+		Method:   http.MethodGet,
+		Endpoint: EndpointGetDomesticCitiesKey,
+		Path:     s.client.resolve(EndpointGetDomesticCitiesKey, countryCode),
+		Result:   &cities,
 	}
 
 	err := s.client.Do(ctx, req)
@@ -1915,10 +2109,11 @@ type testService struct {
 func (s *testService) CreateTestOrder(ctx context.Context, req TestOrderRequest) (*TestOrderResponse, error) {
 	result := &TestOrderResponse{}
 	request := &Request{
-		Method: http.MethodPost,
-		Path:   s.client.resolve(EndpointCreateTestOrderKey),
-		Body:   req,
-		Result: result,
+		Method:   http.MethodPost,
+		Endpoint: EndpointCreateTestOrderKey,
+		Path:     s.client.resolve(EndpointCreateTestOrderKey),
+		Body:     req,
+		Result:   result,
 	}
 
 	err := s.client.Do(ctx, request)
@@ -1931,9 +2126,10 @@ func (s *testService) CreateTestOrder(ctx context.Context, req TestOrderRequest)
 
 func (s *testService) UpdateTestOrderStatus(ctx context.Context, packageID int64, req UpdatePackageStatusRequest) error {
 	request := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointUpdateTestOrderStatusKey, s.client.sellerID, packageID),
-		Body:   req,
+		Method:   http.MethodPut,
+		Endpoint: EndpointUpdateTestOrderStatusKey,
+		Path:     s.client.resolve(EndpointUpdateTestOrderStatusKey, s.client.sellerID, packageID),
+		Body:     req,
 	}
 
 	return s.client.Do(ctx, request)
@@ -1945,9 +2141,10 @@ func (s *testService) SetClaimWaitingInAction(ctx context.Context, shipmentPacka
 	}
 
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointTestClaimWaitingInActionKey, s.client.sellerID),
-		Body:   body,
+		Method:   http.MethodPut,
+		Endpoint: EndpointTestClaimWaitingInActionKey,
+		Path:     s.client.resolve(EndpointTestClaimWaitingInActionKey, s.client.sellerID),
+		Body:     body,
 	}
 
 	return s.client.Do(ctx, req)
@@ -1976,8 +2173,8 @@ type Order struct {
 	IdentityNumber                   string           `json:"identityNumber"`
 	CurrencyCode                     string           `json:"currencyCode"`
 	PackageHistories                 []PackageHistory `json:"packageHistories"`
-	ShipmentPackageStatus            string           `json:"shipmentPackageStatus"`
-	Status                           string           `json:"status"`
+	ShipmentPackageStatus            PackageStatus    `json:"shipmentPackageStatus"`
+	Status                           PackageStatus    `json:"status"`
 	DeliveryType                     string           `json:"deliveryType"`
 	TimeSlotID                       int              `json:"timeSlotId"`
 	ScheduledDeliveryStoreID         string           `json:"scheduledDeliveryStoreId"`
@@ -2079,8 +2276,8 @@ type DiscountDetail struct {
 
 // PackageHistory represents the status history of a package
 type PackageHistory struct {
-	CreatedDate int64  `json:"createdDate"`
-	Status      string `json:"status"`
+	CreatedDate int64         `json:"createdDate"`
+	Status      PackageStatus `json:"status"`
 }
 
 // ShipmentPackage represents a shipment package in the old API structure
@@ -2188,6 +2385,8 @@ type WebhookService interface {
 	Delete(ctx context.Context, id string) error
 	Activate(ctx context.Context, id string) error
 	Deactivate(ctx context.Context, id string) error
+	// Reconcile converges subscriptions to the desired state, matching them by URL
+	Reconcile(ctx context.Context, desired []CreateWebhookRequest, opts ...ReconcileOption) ([]WebhookChange, error)
 }
 
 type webhookService struct {
@@ -2199,10 +2398,11 @@ func (s *webhookService) Create(ctx context.Context, body CreateWebhookRequest)
 		ID string `json:"id"`
 	}
 	req := &Request{
-		Method: http.MethodPost,
-		Path:   s.client.resolve(EndpointCreateWebhookKey, s.client.sellerID),
-		Body:   body,
-		Result: &resp,
+		Method:   http.MethodPost,
+		Endpoint: EndpointCreateWebhookKey,
+		Path:     s.client.resolve(EndpointCreateWebhookKey, s.client.sellerID),
+		Body:     body,
+		Result:   &resp,
 	}
 	if err := s.client.Do(ctx, req); err != nil {
 		return "", err
@@ -2213,9 +2413,10 @@ func (s *webhookService) Create(ctx context.Context, body CreateWebhookRequest)
 func (s *webhookService) List(ctx context.Context) ([]Webhook, error) {
 	var result []Webhook
 	req := &Request{
-		Method: http.MethodGet,
-		Path:   s.client.resolve(EndpointListWebhooksKey, s.client.sellerID),
-		Result: &result,
+		Method:   http.MethodGet,
+		Endpoint: EndpointListWebhooksKey,
+		Path:     s.client.resolve(EndpointListWebhooksKey, s.client.sellerID),
+		Result:   &result,
 	}
 	if err := s.client.Do(ctx, req); err != nil {
 		return nil, err
@@ -2225,33 +2426,37 @@ func (s *webhookService) List(ctx context.Context) ([]Webhook, error) {
 
 func (s *webhookService) Update(ctx context.Context, id string, body UpdateWebhookRequest) error {
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointUpdateWebhookKey, s.client.sellerID, id),
-		Body:   body,
+		Method:   http.MethodPut,
+		Endpoint: EndpointUpdateWebhookKey,
+		Path:     s.client.resolve(EndpointUpdateWebhookKey, s.client.sellerID, id),
+		Body:     body,
 	}
 	return s.client.Do(ctx, req)
 }
 
 func (s *webhookService) Delete(ctx context.Context, id string) error {
 	req := &Request{
-		Method: http.MethodDelete,
-		Path:   s.client.resolve(EndpointDeleteWebhookKey, s.client.sellerID, id),
+		Method:   http.MethodDelete,
+		Endpoint: EndpointDeleteWebhookKey,
+		Path:     s.client.resolve(EndpointDeleteWebhookKey, s.client.sellerID, id),
 	}
 	return s.client.Do(ctx, req)
 }
 
 func (s *webhookService) Activate(ctx context.Context, id string) error {
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointActivateWebhookKey, s.client.sellerID, id),
+		Method:   http.MethodPut,
+		Endpoint: EndpointActivateWebhookKey,
+		Path:     s.client.resolve(EndpointActivateWebhookKey, s.client.sellerID, id),
 	}
 	return s.client.Do(ctx, req)
 }
 
 func (s *webhookService) Deactivate(ctx context.Context, id string) error {
 	req := &Request{
-		Method: http.MethodPut,
-		Path:   s.client.resolve(EndpointDeactivateWebhookKey, s.client.sellerID, id),
+		Method:   http.MethodPut,
+		Endpoint: EndpointDeactivateWebhookKey,
+		Path:     s.client.resolve(EndpointDeactivateWebhookKey, s.client.sellerID, id),
 	}
 	return s.client.Do(ctx, req)
 }
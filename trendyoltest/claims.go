@@ -0,0 +1,341 @@
+package trendyoltest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+// Claim item statuses used by the fake
+const (
+	claimItemCreated         = trendyol.ClaimItemStatusCreated
+	claimItemWaitingInAction = trendyol.ClaimItemStatusWaitingInAction
+	claimItemAccepted        = trendyol.ClaimItemStatusAccepted
+	claimItemRejected        = trendyol.ClaimItemStatusRejected
+)
+
+// ClaimAudit is a single status change of a claim item
+type ClaimAudit struct {
+	ClaimID        string `json:"claimId"`
+	ClaimItemID    string `json:"claimItemId"`
+	PreviousStatus string `json:"previousStatus"`
+	NewStatus      string `json:"newStatus"`
+	ExecutorApp    string `json:"executorApp"`
+	ExecutorUser   string `json:"executorUser"`
+	Date           int64  `json:"date"`
+}
+
+// claimState keeps a claim together with the statuses of its claim item
+// details, copied into the details when listed
+type claimState struct {
+	claim      trendyol.Claim
+	packageID  int64
+	itemStatus map[int64]string
+}
+
+var claimReasons = []trendyol.ClaimReason{
+	{ClaimIssueReasonID: 1, Reason: "İade gelen ürün sahte"},
+	{ClaimIssueReasonID: 51, Reason: "İade gelen ürün kullanılmış"},
+	{ClaimIssueReasonID: 101, Reason: "İade gelen ürün hasarlı"},
+	{ClaimIssueReasonID: 151, Reason: "İade paketi boş geldi"},
+	{ClaimIssueReasonID: 201, Reason: "İade gelen ürün eksik"},
+	{ClaimIssueReasonID: 251, Reason: "İade gelen ürün yanlış"},
+}
+
+// AddClaim stores a claim whose claim item details start in the Created
+// status and returns it with identifiers filled in. An item without details
+// gets a single one.
+func (s *Server) AddClaim(c trendyol.Claim) trendyol.Claim {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.putClaim(c, 0, claimItemCreated)
+}
+
+// ClaimItemStatus returns the current status of a claim item detail
+func (s *Server) ClaimItemStatus(itemID int64) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cs := range s.claims {
+		if st, ok := cs.itemStatus[itemID]; ok {
+			return st, true
+		}
+	}
+	return "", false
+}
+
+// putClaim stores a claim with all items in the given status; callers hold s.mu
+func (s *Server) putClaim(c trendyol.Claim, packageID int64, status string) trendyol.Claim {
+	now := s.nowMillis()
+	if c.ID == 0 {
+		c.ID = 5000000000 + s.nextID()
+	}
+	if c.CreatedDate == 0 {
+		c.CreatedDate = now
+	}
+	if c.ClaimDate == 0 {
+		c.ClaimDate = c.CreatedDate
+	}
+	c.LastModifiedDate = now
+	c.Status = status
+	c.Items = copyClaimItems(c.Items)
+	cs := &claimState{claim: c, packageID: packageID, itemStatus: map[int64]string{}}
+	for i := range cs.claim.Items {
+		it := &cs.claim.Items[i]
+		if len(it.ClaimItems) == 0 {
+			it.ClaimItems = []trendyol.ClaimItemDetail{{}}
+		}
+		for j := range it.ClaimItems {
+			d := &it.ClaimItems[j]
+			if d.ID == 0 {
+				d.ID = 6000000000 + s.nextID()
+			}
+			if d.OrderLineItemID == 0 {
+				d.OrderLineItemID = it.OrderLine.ID
+			}
+			cs.itemStatus[d.ID] = status
+		}
+	}
+	s.claims = append(s.claims, cs)
+	return cs.claim
+}
+
+// copyClaimItems copies items together with their details
+func copyClaimItems(items []trendyol.ClaimItem) []trendyol.ClaimItem {
+	out := append([]trendyol.ClaimItem(nil), items...)
+	for i := range out {
+		out[i].ClaimItems = append([]trendyol.ClaimItemDetail(nil), out[i].ClaimItems...)
+	}
+	return out
+}
+
+// setClaimItemStatus changes an item status and records the audit; callers hold s.mu
+func (s *Server) setClaimItemStatus(cs *claimState, itemID int64, status string) {
+	prev := cs.itemStatus[itemID]
+	now := s.nowMillis()
+	cs.itemStatus[itemID] = status
+	cs.claim.Status = status
+	cs.claim.LastModifiedDate = now
+	s.audits[itemID] = append(s.audits[itemID], ClaimAudit{
+		ClaimID:        strconv.FormatInt(cs.claim.ID, 10),
+		ClaimItemID:    strconv.FormatInt(itemID, 10),
+		PreviousStatus: prev,
+		NewStatus:      status,
+		ExecutorApp:    "SellerIntegrationApi",
+		ExecutorUser:   s.sellerID,
+		Date:           now,
+	})
+}
+
+// findClaim returns the claim with the ID in path parameter p1; callers hold s.mu
+func (s *Server) findClaim(id string) *claimState {
+	for _, cs := range s.claims {
+		if strconv.FormatInt(cs.claim.ID, 10) == id {
+			return cs
+		}
+	}
+	return nil
+}
+
+func (s *Server) listClaims(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	status := q.Get("claimItemStatus")
+	orderNumber := q.Get("orderNumber")
+	start, hasStart := queryMillis(q, "startDate")
+	end, hasEnd := queryMillis(q, "endDate")
+	claimIDs := map[string]bool{}
+	for _, id := range q["claimIds"] {
+		claimIDs[id] = true
+	}
+
+	s.mu.Lock()
+	var matched []trendyol.Claim
+	for _, cs := range s.claims {
+		if status != "" {
+			found := false
+			for _, st := range cs.itemStatus {
+				if st == status {
+					found = true
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		if orderNumber != "" && cs.claim.OrderNumber != orderNumber {
+			continue
+		}
+		if len(claimIDs) > 0 && !claimIDs[strconv.FormatInt(cs.claim.ID, 10)] {
+			continue
+		}
+		if (hasStart && cs.claim.ClaimDate < start) || (hasEnd && cs.claim.ClaimDate > end) {
+			continue
+		}
+		c := cs.claim
+		c.Items = copyClaimItems(cs.claim.Items)
+		for i := range c.Items {
+			for j := range c.Items[i].ClaimItems {
+				d := &c.Items[i].ClaimItems[j]
+				st := cs.itemStatus[d.ID]
+				d.Status = trendyol.ClaimItemStatus{Name: st}
+				d.Resolved = st == claimItemAccepted || st == claimItemRejected
+				d.AcceptedBySeller = st == claimItemAccepted
+			}
+		}
+		matched = append(matched, c)
+	}
+	s.mu.Unlock()
+
+	content, meta := paginate(matched, queryInt(q, "page", 0), queryInt(q, "size", 50))
+	writeJSON(w, http.StatusOK, struct {
+		trendyol.PaginatedResponse
+		Content []trendyol.Claim `json:"content"`
+	}{meta, content})
+}
+
+func (s *Server) approveClaim(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ClaimLineItemIDList []int64           `json:"claimLineItemIdList"`
+		Params              map[string]string `json:"params"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.transitionClaimItems(w, r, body.ClaimLineItemIDList, claimItemAccepted)
+}
+
+func (s *Server) rejectClaim(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	reasonID, err := strconv.Atoi(q.Get("claimIssueReasonId"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "claimIssueReasonId is required", "claimIssueReasonId")
+		return
+	}
+	known := false
+	for _, reason := range claimReasons {
+		if reason.ClaimIssueReasonID == reasonID {
+			known = true
+		}
+	}
+	if !known {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("unknown claim issue reason %d", reasonID), "claimIssueReasonId")
+		return
+	}
+	var ids []int64
+	for _, v := range q["claimItemIdList"] {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "invalid claim item id "+v, "claimItemIdList")
+			return
+		}
+		ids = append(ids, id)
+	}
+	s.transitionClaimItems(w, r, ids, claimItemRejected)
+}
+
+// transitionClaimItems moves WaitingInAction items of the claim in the path to status
+func (s *Server) transitionClaimItems(w http.ResponseWriter, r *http.Request, itemIDs []int64, status string) {
+	if len(itemIDs) == 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "claim item list cannot be empty")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cs := s.findClaim(r.PathValue("p1"))
+	if cs == nil {
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, "claim not found: "+r.PathValue("p1"))
+		return
+	}
+	for _, id := range itemIDs {
+		st, ok := cs.itemStatus[id]
+		if !ok {
+			writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("claim item %d does not belong to claim %d", id, cs.claim.ID))
+			return
+		}
+		if st != claimItemWaitingInAction {
+			writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("claim item %d is in status %s, expected %s", id, st, claimItemWaitingInAction))
+			return
+		}
+	}
+	for _, id := range itemIDs {
+		s.setClaimItemStatus(cs, id, status)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) listClaimReasons(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, claimReasons)
+}
+
+func (s *Server) getClaimAudit(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathInt64(w, r, "p1")
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	audits := append([]ClaimAudit{}, s.audits[id]...)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, audits)
+}
+
+func (s *Server) claimWaitingInAction(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ShipmentPackageID int64 `json:"shipmentPackageId"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.findOrder(body.ShipmentPackageID)
+	if o == nil {
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, fmt.Sprintf("shipment package not found: %d", body.ShipmentPackageID))
+		return
+	}
+	for _, cs := range s.claims {
+		if cs.packageID == o.ID {
+			for id, st := range cs.itemStatus {
+				if st == claimItemCreated {
+					s.setClaimItemStatus(cs, id, claimItemWaitingInAction)
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	c := trendyol.Claim{
+		OrderNumber:            o.OrderNumber,
+		OrderDate:              o.OrderDate,
+		OrderShipmentPackageID: o.ID,
+		CustomerFirstName:      o.CustomerFirstName,
+		CustomerLastName:       o.CustomerLastName,
+		CargoTrackingNumber:    7340000000000000 + s.nextID(),
+		CargoProviderName:      o.CargoProviderName,
+	}
+	// Every unit of a line is a separate claim item detail
+	for _, l := range o.Lines {
+		it := trendyol.ClaimItem{OrderLine: trendyol.ClaimOrderLine{
+			ID:            l.ID,
+			ProductName:   l.ProductName,
+			Barcode:       l.Barcode,
+			MerchantSKU:   l.MerchantSKU,
+			ProductColor:  l.ProductColor,
+			ProductSize:   l.ProductSize,
+			Price:         l.Price,
+			VATBaseAmount: l.VATBaseAmount,
+		}}
+		for n := 0; n < max(l.Quantity, 1); n++ {
+			it.ClaimItems = append(it.ClaimItems, trendyol.ClaimItemDetail{
+				CustomerReason: &trendyol.ClaimItemReason{ID: 1651, Name: "Vazgeçtim", Code: "GIVEN_UP"},
+				CustomerNote:   "Test iadesi",
+			})
+		}
+		c.Items = append(c.Items, it)
+	}
+	s.putClaim(c, o.ID, claimItemWaitingInAction)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,135 @@
+package trendyoltest
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+// MaxSettlementRange is the longest startDate/endDate window the settlements
+// endpoint accepts
+const MaxSettlementRange = trendyol.MaxSettlementWindow
+
+// AddSettlements appends settlement records served by GetSettlements
+func (s *Server) AddSettlements(rows ...trendyol.Settlement) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settlements = append(s.settlements, rows...)
+}
+
+// AddOtherFinancials appends transactions served by GetOtherFinancials
+func (s *Server) AddOtherFinancials(rows ...trendyol.OtherFinancial) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.otherFinancials = append(s.otherFinancials, rows...)
+}
+
+// SetCargoInvoice sets the package details served for a cargo invoice serial number
+func (s *Server) SetCargoInvoice(invoiceSerialNumber string, details []trendyol.CargoInvoiceDetail) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cargo[invoiceSerialNumber] = append([]trendyol.CargoInvoiceDetail(nil), details...)
+}
+
+// financeRange validates the date range and reads the payment order filter
+// shared by the finance endpoints
+func financeRange(w http.ResponseWriter, q url.Values) (start, end, paymentOrderID int64, ok bool) {
+	start, okStart := queryMillis(q, "startDate")
+	end, okEnd := queryMillis(q, "endDate")
+	if !okStart || !okEnd {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "startDate and endDate are required", "startDate")
+		return 0, 0, 0, false
+	}
+	if end < start {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "endDate must be after startDate", "endDate")
+		return 0, 0, 0, false
+	}
+	if time.Duration(end-start)*time.Millisecond > MaxSettlementRange {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "date range cannot be longer than 15 days", "endDate")
+		return 0, 0, 0, false
+	}
+	paymentOrderID, _ = queryMillis(q, "paymentOrderId")
+	return start, end, paymentOrderID, true
+}
+
+func (s *Server) listSettlements(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	start, end, paymentOrderID, ok := financeRange(w, q)
+	if !ok {
+		return
+	}
+	types := map[string]bool{}
+	for _, t := range q["transactionType"] {
+		types[t] = true
+	}
+
+	s.mu.Lock()
+	var matched []trendyol.Settlement
+	for _, row := range s.settlements {
+		if row.SettlementDate < start || row.SettlementDate > end {
+			continue
+		}
+		if len(types) > 0 && !types[row.TransactionType] {
+			continue
+		}
+		if paymentOrderID != 0 && row.PaymentOrderID != paymentOrderID {
+			continue
+		}
+		matched = append(matched, row)
+	}
+	s.mu.Unlock()
+
+	content, meta := paginate(matched, queryInt(q, "page", 0), queryInt(q, "size", 500))
+	writeJSON(w, http.StatusOK, struct {
+		trendyol.PaginatedResponse
+		Content []trendyol.Settlement `json:"content"`
+	}{meta, content})
+}
+
+// listOtherFinancials requires a transaction type like the real endpoint
+func (s *Server) listOtherFinancials(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	start, end, paymentOrderID, ok := financeRange(w, q)
+	if !ok {
+		return
+	}
+	txType := q.Get("transactionType")
+	if txType == "" {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "transactionType is required", "transactionType")
+		return
+	}
+
+	s.mu.Lock()
+	var matched []trendyol.OtherFinancial
+	for _, row := range s.otherFinancials {
+		if row.TransactionDate < start || row.TransactionDate > end || row.TransactionType != txType {
+			continue
+		}
+		if paymentOrderID != 0 && row.PaymentOrderID != paymentOrderID {
+			continue
+		}
+		matched = append(matched, row)
+	}
+	s.mu.Unlock()
+
+	content, meta := paginate(matched, queryInt(q, "page", 0), queryInt(q, "size", 500))
+	writeJSON(w, http.StatusOK, struct {
+		trendyol.PaginatedResponse
+		Content []trendyol.OtherFinancial `json:"content"`
+	}{meta, content})
+}
+
+func (s *Server) getCargoInvoiceDetails(w http.ResponseWriter, r *http.Request) {
+	serial := r.PathValue("p1")
+	s.mu.Lock()
+	details, ok := s.cargo[serial]
+	details = append([]trendyol.CargoInvoiceDetail{}, details...)
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, "cargo invoice not found: "+serial)
+		return
+	}
+	writeJSON(w, http.StatusOK, details)
+}
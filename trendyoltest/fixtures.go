@@ -0,0 +1,157 @@
+package trendyoltest
+
+import (
+	"net/http"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+// Fixture identifiers that tests can reference when building products.
+const (
+	FixtureBrandID         = 1791
+	FixtureCategoryID      = 411
+	FixtureOriginAttribute = 1192
+	FixtureOriginTR        = 10617344
+	FixtureColorAttribute  = 348
+)
+
+type shipmentProvider struct {
+	trendyol.ShipmentProvider
+	code string
+}
+
+var shipmentProviders = []shipmentProvider{
+	{trendyol.ShipmentProvider{ID: 4, Name: "Yurtiçi Kargo Marketplace"}, "YKMP"},
+	{trendyol.ShipmentProvider{ID: 7, Name: "Aras Kargo Marketplace"}, "ARASMP"},
+	{trendyol.ShipmentProvider{ID: 9, Name: "Sürat Kargo Marketplace"}, "SURATMP"},
+	{trendyol.ShipmentProvider{ID: 10, Name: "MNG Kargo Marketplace"}, "MNGMP"},
+	{trendyol.ShipmentProvider{ID: 17, Name: "Trendyol Express Marketplace"}, "TEXMP"},
+	{trendyol.ShipmentProvider{ID: 19, Name: "PTT Kargo Marketplace"}, "PTTMP"},
+}
+
+var countries = []trendyol.Country{
+	{ID: 1, Code: "TR", Name: "Türkiye"},
+	{ID: 2, Code: "AZ", Name: "Azerbaycan"},
+	{ID: 3, Code: "DE", Name: "Almanya"},
+}
+
+var cities = map[string][]trendyol.City{
+	"TR": {
+		{ID: 34, Name: "İstanbul", CountryID: 1, Code: "34"},
+		{ID: 6, Name: "Ankara", CountryID: 1, Code: "06"},
+		{ID: 35, Name: "İzmir", CountryID: 1, Code: "35"},
+	},
+	"AZ": {
+		{ID: 1001, Name: "Bakü", CountryID: 2},
+	},
+	"DE": {
+		{ID: 2001, Name: "Berlin", CountryID: 3},
+	},
+}
+
+// seedFixtures fills reference data: brands, categories, attributes and addresses
+func (s *Server) seedFixtures() {
+	s.brands = []trendyol.Brand{
+		{ID: FixtureBrandID, Name: "TrendyolMilla"},
+		{ID: 2209541, Name: "Go SDK Test"},
+		{ID: 40, Name: "Koton"},
+		{ID: 102, Name: "LC Waikiki"},
+	}
+	s.categories = []trendyol.Category{
+		{ID: 403, Name: "Giyim"},
+		{ID: FixtureCategoryID, Name: "Sweatshirt", ParentID: 403},
+		{ID: 2927, Name: "Davetiye"},
+	}
+	s.attributes[FixtureCategoryID] = []trendyol.CategoryAttribute{
+		{
+			AttributeID:   FixtureOriginAttribute,
+			AttributeName: "Menşei",
+			Required:      true,
+			AttributeValues: []trendyol.AttributeValue{
+				{AttributeValueID: FixtureOriginTR, Value: "TR"},
+				{AttributeValueID: 10617345, Value: "CN"},
+			},
+		},
+		{
+			AttributeID:      FixtureColorAttribute,
+			AttributeName:    "Renk",
+			AllowCustomValue: true,
+			AttributeValues: []trendyol.AttributeValue{
+				{AttributeValueID: 1001, Value: "Siyah"},
+				{AttributeValueID: 1002, Value: "Beyaz"},
+			},
+		},
+	}
+	s.attributes[2927] = []trendyol.CategoryAttribute{
+		{
+			AttributeID:   FixtureOriginAttribute,
+			AttributeName: "Menşei",
+			Required:      true,
+			AttributeValues: []trendyol.AttributeValue{
+				{AttributeValueID: FixtureOriginTR, Value: "TR"},
+			},
+		},
+	}
+	s.addresses = []trendyol.Address{
+		{
+			ID: 1, AddressType: "Shipment", Country: "Türkiye", City: "İstanbul", CityCode: 34,
+			District: "Kağıthane", DistrictID: 1, PostCode: "34400", Address: "Test Mah. Depo Sk. No:1",
+			FullAddress: "Test Mah. Depo Sk. No:1 Kağıthane/İstanbul", IsDefault: true, IsShipmentAddress: true,
+		},
+		{
+			ID: 2, AddressType: "Returning", Country: "Türkiye", City: "İstanbul", CityCode: 34,
+			District: "Kağıthane", DistrictID: 1, PostCode: "34400", Address: "Test Mah. İade Sk. No:2",
+			FullAddress: "Test Mah. İade Sk. No:2 Kağıthane/İstanbul", IsReturningAddress: true,
+		},
+		{
+			ID: 3, AddressType: "Invoice", Country: "Türkiye", City: "İstanbul", CityCode: 34,
+			District: "Şişli", DistrictID: 2, PostCode: "34360", Address: "Test Mah. Fatura Sk. No:3",
+			FullAddress: "Test Mah. Fatura Sk. No:3 Şişli/İstanbul", IsInvoiceAddress: true,
+		},
+	}
+}
+
+func (s *Server) listAddresses(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	addresses := append([]trendyol.Address(nil), s.addresses...)
+	s.mu.Unlock()
+
+	resp := map[string]interface{}{
+		"supplierAddresses":       addresses,
+		"defaultReturningAddress": map[string]bool{"present": false},
+	}
+	for i := range addresses {
+		a := addresses[i]
+		if a.IsShipmentAddress && a.IsDefault {
+			resp["defaultShipmentAddress"] = a
+		}
+		if a.IsInvoiceAddress {
+			resp["defaultInvoiceAddress"] = a
+		}
+		if a.IsReturningAddress {
+			resp["defaultReturningAddress"] = map[string]bool{"present": true}
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) listCountries(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, countries)
+}
+
+func (s *Server) listCountryCities(w http.ResponseWriter, r *http.Request) {
+	list, ok := cities[r.PathValue("p0")]
+	if !ok {
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, "country not found: "+r.PathValue("p0"))
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) listShipmentProviders(w http.ResponseWriter, r *http.Request) {
+	out := make([]trendyol.ShipmentProvider, len(shipmentProviders))
+	for i, p := range shipmentProviders {
+		out[i] = p.ShipmentProvider
+	}
+	writeJSON(w, http.StatusOK, out)
+}
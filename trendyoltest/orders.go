@@ -0,0 +1,894 @@
+package trendyoltest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+// sellerTransitions lists the package statuses a seller may set through the
+// UpdatePackageStatus endpoint, keyed by the required current status.
+var sellerTransitions = map[trendyol.PackageStatus]trendyol.PackageStatus{
+	trendyol.StatusPicking:  trendyol.StatusCreated,
+	trendyol.StatusInvoiced: trendyol.StatusPicking,
+}
+
+// AddOrder stores a shipment package and returns it with server-managed
+// fields filled in: package, line and tracking identifiers, Created status and
+// its history entry when missing.
+func (s *Server) AddOrder(o trendyol.Order) trendyol.Order {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.putOrder(o)
+}
+
+// Order returns the shipment package with the given ID
+func (s *Server) Order(packageID int64) (trendyol.Order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.findOrder(packageID)
+	if o == nil {
+		return trendyol.Order{}, false
+	}
+	return cloneOrder(o), true
+}
+
+// InvoiceLink returns the invoice link sent for a shipment package
+func (s *Server) InvoiceLink(packageID int64) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	link, ok := s.invoices[packageID]
+	return link, ok
+}
+
+// BoxQuantity returns the box quantity set for a shipment package
+func (s *Server) BoxQuantity(packageID int64) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := s.boxes[packageID]
+	return n, ok
+}
+
+// SetOrderStatus moves a shipment package to the given status without
+// transition checks, as Trendyol does for cargo-driven statuses.
+func (s *Server) SetOrderStatus(packageID int64, status trendyol.PackageStatus) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.findOrder(packageID)
+	if o == nil {
+		return false
+	}
+	s.setStatus(o, status)
+	return true
+}
+
+// putOrder fills server-managed fields and stores the order; callers hold s.mu
+func (s *Server) putOrder(o trendyol.Order) *trendyol.Order {
+	now := s.nowMillis()
+	if o.ID == 0 {
+		o.ID = 3000000000 + s.nextID()
+	}
+	if o.OrderNumber == "" {
+		o.OrderNumber = strconv.FormatInt(10000000000+s.nextID(), 10)
+	}
+	if o.CargoTrackingNumber == 0 {
+		o.CargoTrackingNumber = 7330000000000000 + s.nextID()
+	}
+	if o.CargoProviderName == "" {
+		o.CargoProviderName = "Trendyol Express Marketplace"
+	}
+	if o.CurrencyCode == "" {
+		o.CurrencyCode = "TRY"
+	}
+	if o.OrderDate == 0 {
+		o.OrderDate = now
+	}
+	if o.Status == "" {
+		o.Status = trendyol.StatusCreated
+	}
+	o.ShipmentPackageStatus = o.Status
+	if len(o.PackageHistories) == 0 {
+		o.PackageHistories = []trendyol.PackageHistory{{CreatedDate: now, Status: o.Status}}
+	}
+	if o.LastModifiedDate == 0 {
+		o.LastModifiedDate = now
+	}
+	o.Lines = append([]trendyol.OrderLine(nil), o.Lines...)
+	for i := range o.Lines {
+		if o.Lines[i].ID == 0 {
+			o.Lines[i].ID = 4000000000 + s.nextID()
+		}
+		if o.Lines[i].OrderLineItemStatusName == "" {
+			o.Lines[i].OrderLineItemStatusName = string(o.Status)
+		}
+		if o.Lines[i].CurrencyCode == "" {
+			o.Lines[i].CurrencyCode = o.CurrencyCode
+		}
+	}
+	recalcTotals(&o)
+
+	for i, existing := range s.orders {
+		if existing.ID == o.ID {
+			s.orders[i] = &o
+			return &o
+		}
+	}
+	s.orders = append(s.orders, &o)
+	return &o
+}
+
+func recalcTotals(o *trendyol.Order) {
+	var gross, discount, ty float64
+	for _, l := range o.Lines {
+		gross += l.Amount
+		discount += l.Discount
+		ty += l.TyDiscount
+	}
+	o.GrossAmount = gross
+	o.TotalDiscount = discount
+	o.TotalTyDiscount = ty
+	o.TotalPrice = gross - discount
+}
+
+func cloneOrder(o *trendyol.Order) trendyol.Order {
+	c := *o
+	c.Lines = append([]trendyol.OrderLine(nil), o.Lines...)
+	c.PackageHistories = append([]trendyol.PackageHistory(nil), o.PackageHistories...)
+	c.OriginPackageIDs = append([]int64(nil), o.OriginPackageIDs...)
+	return c
+}
+
+// findOrder returns the stored package; callers hold s.mu
+func (s *Server) findOrder(id int64) *trendyol.Order {
+	for _, o := range s.orders {
+		if o.ID == id {
+			return o
+		}
+	}
+	return nil
+}
+
+// findOrderByTracking returns the package with the cargo tracking number; callers hold s.mu
+func (s *Server) findOrderByTracking(tracking string) *trendyol.Order {
+	for _, o := range s.orders {
+		if strconv.FormatInt(o.CargoTrackingNumber, 10) == tracking {
+			return o
+		}
+	}
+	return nil
+}
+
+// setStatus records a status change on the package and its lines; callers hold s.mu
+func (s *Server) setStatus(o *trendyol.Order, status trendyol.PackageStatus) {
+	now := s.nowMillis()
+	if now <= o.LastModifiedDate {
+		now = o.LastModifiedDate + 1
+	}
+	o.Status = status
+	o.ShipmentPackageStatus = status
+	o.LastModifiedDate = now
+	o.PackageHistories = append(o.PackageHistories, trendyol.PackageHistory{CreatedDate: now, Status: status})
+	for i := range o.Lines {
+		o.Lines[i].OrderLineItemStatusName = string(status)
+	}
+}
+
+// touch bumps the modification date of the package; callers hold s.mu
+func (s *Server) touch(o *trendyol.Order) {
+	now := s.nowMillis()
+	if now <= o.LastModifiedDate {
+		now = o.LastModifiedDate + 1
+	}
+	o.LastModifiedDate = now
+}
+
+// lookupOrder resolves the package ID path parameter and writes a 404 when the
+// package is unknown. On success it returns with s.mu held.
+func (s *Server) lookupOrder(w http.ResponseWriter, r *http.Request) *trendyol.Order {
+	id, ok := pathInt64(w, r, "p1")
+	if !ok {
+		return nil
+	}
+	s.mu.Lock()
+	o := s.findOrder(id)
+	if o == nil {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, fmt.Sprintf("shipment package not found: %d", id))
+		return nil
+	}
+	return o
+}
+
+func isSplittable(status trendyol.PackageStatus) bool {
+	return status == trendyol.StatusCreated || status == trendyol.StatusPicking
+}
+
+func (s *Server) listOrders(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	start, hasStart := queryMillis(q, "startDate")
+	end, hasEnd := queryMillis(q, "endDate")
+	packageIDs := map[string]bool{}
+	for _, id := range q["shipmentPackageIds"] {
+		packageIDs[id] = true
+	}
+
+	s.mu.Lock()
+	var matched []trendyol.Order
+	for _, o := range s.orders {
+		if v := q.Get("status"); v != "" && v != string(o.Status) {
+			continue
+		}
+		if v := q.Get("orderNumber"); v != "" && v != o.OrderNumber {
+			continue
+		}
+		if len(packageIDs) > 0 && !packageIDs[strconv.FormatInt(o.ID, 10)] {
+			continue
+		}
+		if hasStart && o.LastModifiedDate < start {
+			continue
+		}
+		if hasEnd && o.LastModifiedDate > end {
+			continue
+		}
+		matched = append(matched, cloneOrder(o))
+	}
+	s.mu.Unlock()
+
+	desc := q.Get("orderByDirection") == "DESC"
+	byCreated := q.Get("orderByField") == "CreatedDate"
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i].LastModifiedDate, matched[j].LastModifiedDate
+		if byCreated {
+			a, b = matched[i].OrderDate, matched[j].OrderDate
+		}
+		if desc {
+			return a > b
+		}
+		return a < b
+	})
+
+	content, meta := paginate(matched, queryInt(q, "page", 0), queryInt(q, "size", 50))
+	writeJSON(w, http.StatusOK, struct {
+		trendyol.PaginatedResponse
+		Content []trendyol.Order `json:"content"`
+	}{meta, content})
+}
+
+func (s *Server) updatePackageStatus(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.UpdatePackageStatusRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	required, allowed := sellerTransitions[body.Status]
+	if !allowed {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "status "+string(body.Status)+" cannot be set by the seller", "status")
+		return
+	}
+	if o.Status != required {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("package in status %s cannot be moved to %s", o.Status, body.Status), "status")
+		return
+	}
+	if len(body.Lines) == 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "lines cannot be empty", "lines")
+		return
+	}
+	for _, l := range body.Lines {
+		found := false
+		for _, ol := range o.Lines {
+			if ol.ID == l.LineID {
+				found = true
+				if l.Quantity <= 0 || l.Quantity > ol.Quantity {
+					writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("invalid quantity %d for line %d", l.Quantity, l.LineID), "lines")
+					return
+				}
+			}
+		}
+		if !found {
+			writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("line %d does not belong to package %d", l.LineID, o.ID), "lines")
+			return
+		}
+	}
+	if body.Status == trendyol.StatusInvoiced && body.Params["invoiceNumber"] == "" {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "invoiceNumber param is required", "params.invoiceNumber")
+		return
+	}
+
+	s.setStatus(o, body.Status)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) updateTrackingNumber(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.TrackingNumberRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	tracking, err := strconv.ParseInt(body.TrackingNumber, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "trackingNumber must be numeric", "trackingNumber")
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	o.CargoTrackingNumber = tracking
+	s.touch(o)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) cancelPackageItems(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Lines    []trendyol.CancelPackageLine `json:"lines"`
+		ReasonID int                          `json:"reasonId"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	if !isSplittable(o.Status) {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "items of a package in status "+string(o.Status)+" cannot be cancelled")
+		return
+	}
+	for _, c := range body.Lines {
+		idx := -1
+		for i, l := range o.Lines {
+			if l.ID == c.LineID {
+				idx = i
+			}
+		}
+		if idx < 0 || c.Quantity <= 0 || c.Quantity > o.Lines[idx].Quantity {
+			writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("invalid cancel line %d", c.LineID), "lines")
+			return
+		}
+	}
+	for _, c := range body.Lines {
+		for i := range o.Lines {
+			if o.Lines[i].ID == c.LineID {
+				o.Lines[i].Quantity -= c.Quantity
+			}
+		}
+	}
+	remaining := o.Lines[:0]
+	for _, l := range o.Lines {
+		if l.Quantity > 0 {
+			remaining = append(remaining, l)
+		}
+	}
+	o.Lines = remaining
+	recalcTotals(o)
+	if len(o.Lines) == 0 {
+		s.setStatus(o, trendyol.StatusCancelled)
+	} else {
+		s.touch(o)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// splitOff moves the given lines of o into a new package; callers hold s.mu
+func (s *Server) splitOff(o *trendyol.Order, lines []trendyol.OrderLine) int64 {
+	n := cloneOrder(o)
+	n.ID = 0
+	n.CargoTrackingNumber = 0
+	n.Lines = lines
+	n.PackageHistories = nil
+	n.LastModifiedDate = 0
+	n.Status = trendyol.StatusCreated
+	n.OriginPackageIDs = []int64{o.ID}
+	return s.putOrder(n).ID
+}
+
+func (s *Server) splitPackage(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		OrderLineIDs []int64 `json:"orderLineIds"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	if !isSplittable(o.Status) {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "package in status "+string(o.Status)+" cannot be split")
+		return
+	}
+	move := map[int64]bool{}
+	for _, id := range body.OrderLineIDs {
+		move[id] = true
+	}
+	var moved, kept []trendyol.OrderLine
+	for _, l := range o.Lines {
+		if move[l.ID] {
+			moved = append(moved, l)
+		} else {
+			kept = append(kept, l)
+		}
+	}
+	if len(moved) != len(move) || len(moved) == 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "orderLineIds must reference lines of the package", "orderLineIds")
+		return
+	}
+	if len(kept) == 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "at least one line must remain in the package", "orderLineIds")
+		return
+	}
+	o.Lines = kept
+	recalcTotals(o)
+	s.touch(o)
+	s.splitOff(o, moved)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) multiSplitPackage(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		SplitGroups []trendyol.SplitGroup `json:"splitGroups"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	if !isSplittable(o.Status) {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "package in status "+string(o.Status)+" cannot be split")
+		return
+	}
+	byID := map[int64]trendyol.OrderLine{}
+	for _, l := range o.Lines {
+		byID[l.ID] = l
+	}
+	used := map[int64]bool{}
+	groups := make([][]trendyol.OrderLine, len(body.SplitGroups))
+	for i, g := range body.SplitGroups {
+		for _, id := range g.OrderLineIDs {
+			l, ok := byID[id]
+			if !ok || used[id] {
+				writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("invalid order line %d in split groups", id), "splitGroups")
+				return
+			}
+			used[id] = true
+			groups[i] = append(groups[i], l)
+		}
+	}
+	if len(used) != len(o.Lines) || len(groups) < 2 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "split groups must cover every line in at least two groups", "splitGroups")
+		return
+	}
+	for _, g := range groups {
+		s.splitOff(o, g)
+	}
+	s.setStatus(o, trendyol.StatusUnpacked)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) quantitySplitPackage(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		QuantitySplit []trendyol.QuantitySplit `json:"quantitySplit"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	if !isSplittable(o.Status) {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "package in status "+string(o.Status)+" cannot be split")
+		return
+	}
+	splits := map[int64][]int{}
+	packages := 0
+	for _, qs := range body.QuantitySplit {
+		splits[qs.OrderLineID] = qs.Quantities
+		if len(qs.Quantities) > packages {
+			packages = len(qs.Quantities)
+		}
+	}
+	if packages < 2 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "quantity split must produce at least two packages", "quantitySplit")
+		return
+	}
+	groups := make([][]trendyol.OrderLine, packages)
+	for _, l := range o.Lines {
+		qs, ok := splits[l.ID]
+		if !ok {
+			groups[0] = append(groups[0], l)
+			continue
+		}
+		total := 0
+		for _, q := range qs {
+			total += q
+		}
+		if total != l.Quantity {
+			writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("quantities for line %d must add up to %d", l.ID, l.Quantity), "quantitySplit")
+			return
+		}
+		for i, q := range qs {
+			if q <= 0 {
+				continue
+			}
+			part := l
+			part.ID = 0
+			part.Quantity = q
+			groups[i] = append(groups[i], part)
+		}
+	}
+	for _, g := range groups {
+		if len(g) > 0 {
+			s.splitOff(o, g)
+		}
+	}
+	s.setStatus(o, trendyol.StatusUnpacked)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) updateBoxInfo(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		BoxQuantity int     `json:"boxQuantity"`
+		Deci        float64 `json:"deci"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.BoxQuantity <= 0 || body.Deci <= 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "boxQuantity and deci must be positive")
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	o.CargoDeci = body.Deci
+	s.boxes[o.ID] = body.BoxQuantity
+	s.touch(o)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) alternativeDelivery(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.AlternativeDeliveryRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.TrackingInfo == "" {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "trackingInfo is required", "trackingInfo")
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	if o.Status != trendyol.StatusInvoiced && o.Status != trendyol.StatusPicking {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "package in status "+string(o.Status)+" cannot be shipped")
+		return
+	}
+	if body.Deci != nil {
+		o.CargoDeci = *body.Deci
+	}
+	if body.BoxQuantity != nil {
+		s.boxes[o.ID] = *body.BoxQuantity
+	}
+	s.setStatus(o, trendyol.StatusShipped)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) manualDeliver(w http.ResponseWriter, r *http.Request) {
+	s.manualTransition(w, r, trendyol.StatusShipped, trendyol.StatusDelivered)
+}
+
+func (s *Server) manualReturn(w http.ResponseWriter, r *http.Request) {
+	s.manualTransition(w, r, trendyol.StatusDelivered, trendyol.StatusReturned)
+}
+
+func (s *Server) manualTransition(w http.ResponseWriter, r *http.Request, from, to trendyol.PackageStatus) {
+	tracking := r.PathValue("p1")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.findOrderByTracking(tracking)
+	if o == nil {
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, "no package with cargo tracking number "+tracking)
+		return
+	}
+	if o.Status != from {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("package in status %s cannot be moved to %s", o.Status, to))
+		return
+	}
+	s.setStatus(o, to)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) updateCargoProvider(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		CargoProvider string `json:"cargoProvider"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	name := body.CargoProvider
+	for _, p := range shipmentProviders {
+		if p.code == body.CargoProvider {
+			name = p.Name
+		}
+	}
+	o.CargoProviderName = name
+	s.touch(o)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) updateWarehouse(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		WarehouseID int `json:"warehouseId"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	o.WarehouseID = body.WarehouseID
+	s.touch(o)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) extendDeliveryDate(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ExtendedDayCount int `json:"extendedDayCount"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	if !o.AgreedDeliveryDateExtendible {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "agreed delivery date of this package is not extendible")
+		return
+	}
+	if body.ExtendedDayCount <= 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "extendedDayCount must be positive", "extendedDayCount")
+		return
+	}
+	base := o.AgreedDeliveryDate
+	if base == 0 {
+		base = s.nowMillis()
+	}
+	o.ExtendedAgreedDeliveryDate = base + int64(body.ExtendedDayCount)*24*60*60*1000
+	o.AgreedDeliveryDateExtendible = false
+	s.touch(o)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) updateLaborCosts(w http.ResponseWriter, r *http.Request) {
+	var body []trendyol.LaborCost
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	s.laborCosts[o.ID] = body
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deliveredByService(w http.ResponseWriter, r *http.Request) {
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	o.DeliveredByService = true
+	s.setStatus(o, trendyol.StatusDelivered)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) sendInvoiceLink(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.InvoiceLinkRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.InvoiceLink == "" {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "invoiceLink is required", "invoiceLink")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.findOrder(body.ShipmentPackageID) == nil {
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, fmt.Sprintf("shipment package not found: %d", body.ShipmentPackageID))
+		return
+	}
+	if _, exists := s.invoices[body.ShipmentPackageID]; exists {
+		writeError(w, http.StatusConflict, trendyol.ErrCodeValidation, "an invoice link was already sent for this package")
+		return
+	}
+	s.invoices[body.ShipmentPackageID] = body.InvoiceLink
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) deleteInvoiceLink(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ShipmentPackageID int64 `json:"shipmentPackageId"`
+		ServiceSourceID   int64 `json:"serviceSourceId"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	id := body.ShipmentPackageID
+	if id == 0 {
+		id = body.ServiceSourceID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.invoices[id]; !ok {
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, fmt.Sprintf("no invoice link for package %d", id))
+		return
+	}
+	delete(s.invoices, id)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) createCommonLabel(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.CommonLabelRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	tracking := r.PathValue("p1")
+	if body.Format == "" {
+		body.Format = "ZPL"
+	}
+	if body.BoxQuantity <= 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "boxQuantity must be positive", "boxQuantity")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.findOrderByTracking(tracking)
+	if o == nil {
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, "no package with cargo tracking number "+tracking)
+		return
+	}
+	var label []byte
+	for i := 1; i <= body.BoxQuantity; i++ {
+		label = append(label, fmt.Sprintf("^XA^FO50,50^A0N,40,40^FD%s^FS^FO50,110^FDBox %d/%d^FS^FO50,170^BCN,100,Y,N,N^FD%s^FS^XZ\n",
+			o.OrderNumber, i, body.BoxQuantity, tracking)...)
+	}
+	s.labels[tracking] = label
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getCommonLabel(w http.ResponseWriter, r *http.Request) {
+	tracking := r.PathValue("p1")
+	s.mu.Lock()
+	label, ok := s.labels[tracking]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, "no label created for cargo tracking number "+tracking)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": []map[string]string{{"label": string(label), "format": "ZPL"}},
+	})
+}
+
+func (s *Server) createTestOrder(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.TestOrderRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if len(body.Lines) == 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "lines cannot be empty", "lines")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := trendyol.Order{
+		CustomerFirstName: body.Customer.CustomerFirstName,
+		CustomerLastName:  body.Customer.CustomerLastName,
+		CustomerEmail:     body.ShippingAddress.Email,
+		Commercial:        body.Commercial,
+		ShipmentAddress:   testOrderAddress(body.ShippingAddress, body.ShippingAddress.ShippingFirstName, body.ShippingAddress.ShippingLastName),
+		InvoiceAddress:    testOrderAddress(body.InvoiceAddress, body.InvoiceAddress.InvoiceFirstName, body.InvoiceAddress.InvoiceLastName),
+	}
+	for _, l := range body.Lines {
+		p, ok := s.products[l.Barcode]
+		if !ok {
+			writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "product not found with barcode "+l.Barcode, "lines")
+			return
+		}
+		discount := p.SalePrice * float64(l.Quantity) * l.DiscountPercentage / 100
+		o.Lines = append(o.Lines, trendyol.OrderLine{
+			Quantity:          l.Quantity,
+			MerchantSKU:       p.StockCode,
+			ProductName:       p.Title,
+			Barcode:           p.Barcode,
+			Amount:            p.SalePrice * float64(l.Quantity),
+			Price:             p.SalePrice,
+			Discount:          discount,
+			ProductCategoryID: p.CategoryID,
+		})
+	}
+	created := s.putOrder(o)
+	writeJSON(w, http.StatusOK, trendyol.TestOrderResponse{OrderNumber: created.OrderNumber, ShipmentPackageID: created.ID})
+}
+
+func testOrderAddress(a trendyol.TestAddress, first, last string) *trendyol.OrderAddress {
+	return &trendyol.OrderAddress{
+		FirstName:    first,
+		LastName:     last,
+		FullName:     first + " " + last,
+		Company:      a.Company,
+		Address1:     a.AddressText,
+		City:         a.City,
+		District:     a.District,
+		Neighborhood: a.Neighborhood,
+		PostalCode:   a.PostalCode,
+		Latitude:     a.Latitude,
+		Longitude:    a.Longitude,
+		CountryCode:  "TR",
+		FullAddress:  a.AddressText + " " + a.District + "/" + a.City,
+	}
+}
+
+func (s *Server) updateTestOrderStatus(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.UpdatePackageStatusRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Status == "" {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "status is required", "status")
+		return
+	}
+	o := s.lookupOrder(w, r)
+	if o == nil {
+		return
+	}
+	defer s.mu.Unlock()
+
+	s.setStatus(o, body.Status)
+	w.WriteHeader(http.StatusOK)
+}
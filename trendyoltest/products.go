@@ -0,0 +1,586 @@
+package trendyoltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+// Limits enforced by the fake for batch product and inventory requests.
+const (
+	MaxProductCreateItems  = trendyol.MaxProductBatchSize
+	MaxPriceInventoryItems = trendyol.MaxPriceInventoryBatchSize
+	MaxQuantity            = 20000
+	MaxTitleLength         = 100
+)
+
+// batchState tracks a submitted batch request and how often it was polled
+type batchState struct {
+	resp  trendyol.BatchStatusResponse
+	polls int
+	final string // status reported once polling completes, COMPLETED when empty
+}
+
+// SetBatchStatus sets the status a batch request reports once its polls are
+// used up, e.g. BatchStatusFailed. It reports false for an unknown batch.
+func (s *Server) SetBatchStatus(batchRequestID, status string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.batches[batchRequestID]
+	if !ok {
+		return false
+	}
+	b.final = status
+	return true
+}
+
+// AddProduct stores a product directly, bypassing batch processing
+func (s *Server) AddProduct(p trendyol.Product) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.putProduct(p)
+}
+
+// Product returns the stored product with the given barcode
+func (s *Server) Product(barcode string) (trendyol.Product, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.products[barcode]
+	if !ok {
+		return trendyol.Product{}, false
+	}
+	return *p, true
+}
+
+// Products returns all stored products in insertion order
+func (s *Server) Products() []trendyol.Product {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]trendyol.Product, 0, len(s.productKeys))
+	for _, bc := range s.productKeys {
+		out = append(out, *s.products[bc])
+	}
+	return out
+}
+
+// SetCategoryAttributes replaces the attribute definitions of a category
+func (s *Server) SetCategoryAttributes(categoryID int, attrs []trendyol.CategoryAttribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes[categoryID] = attrs
+}
+
+// putProduct inserts or replaces a product, filling server-managed fields;
+// callers hold s.mu
+func (s *Server) putProduct(p trendyol.Product) {
+	now := s.nowMillis()
+	if existing, ok := s.products[p.Barcode]; ok {
+		if p.CreateDateTime == 0 {
+			p.CreateDateTime = existing.CreateDateTime
+		}
+		if p.ID == "" {
+			p.ID = existing.ID
+		}
+	} else {
+		s.productKeys = append(s.productKeys, p.Barcode)
+	}
+	if p.ID == "" {
+		p.ID = strings.ReplaceAll(newUUID(), "-", "")
+	}
+	if p.CreateDateTime == 0 {
+		p.CreateDateTime = now
+	}
+	if p.LastUpdateDate == 0 || p.LastUpdateDate < now {
+		p.LastUpdateDate = now
+	}
+	for _, b := range s.brands {
+		if b.ID == p.BrandID && p.Brand == "" {
+			p.Brand = b.Name
+		}
+	}
+	for _, c := range s.categories {
+		if c.ID == p.CategoryID && p.CategoryName == "" {
+			p.CategoryName = c.Name
+		}
+	}
+	p.OnSale = !p.Archived && p.Quantity > 0
+	s.products[p.Barcode] = &p
+}
+
+// newBatch registers a batch request whose items already have their final
+// status; callers hold s.mu
+func (s *Server) newBatch(requestType string, items []trendyol.BatchResponseItem) string {
+	id := newUUID()
+	failed := 0
+	for _, it := range items {
+		if it.Status == trendyol.BatchItemStatusFailed {
+			failed++
+		}
+	}
+	now := s.nowMillis()
+	s.batches[id] = &batchState{resp: trendyol.BatchStatusResponse{
+		BatchRequestID:   id,
+		Status:           trendyol.BatchStatusInProgress,
+		CreationDate:     now,
+		LastModification: now,
+		SourceType:       "API",
+		ItemCount:        len(items),
+		FailedItemCount:  failed,
+		BatchRequestType: requestType,
+		Items:            items,
+	}}
+	return id
+}
+
+func batchItem(requestItem interface{}, reasons []string) trendyol.BatchResponseItem {
+	if len(reasons) > 0 {
+		return trendyol.BatchResponseItem{RequestItem: requestItem, Status: trendyol.BatchItemStatusFailed, FailureReasons: reasons}
+	}
+	return trendyol.BatchResponseItem{RequestItem: requestItem, Status: trendyol.BatchItemStatusSuccess}
+}
+
+// validateProduct applies the content rules the gateway checks for create and
+// update requests; callers hold s.mu
+func (s *Server) validateProduct(p trendyol.Product) []string {
+	var reasons []string
+	if strings.TrimSpace(p.Barcode) == "" {
+		reasons = append(reasons, "barcode is required")
+	}
+	if strings.TrimSpace(p.Title) == "" {
+		reasons = append(reasons, "title is required")
+	} else if len([]rune(p.Title)) > MaxTitleLength {
+		reasons = append(reasons, fmt.Sprintf("title cannot be longer than %d characters", MaxTitleLength))
+	}
+	if p.ProductMainID == "" {
+		reasons = append(reasons, "productMainId is required")
+	}
+	if p.BrandID == 0 {
+		reasons = append(reasons, "brandId is required")
+	}
+	if p.CategoryID == 0 {
+		reasons = append(reasons, "categoryId is required")
+	}
+	if len(p.Images) == 0 {
+		reasons = append(reasons, "at least one image is required")
+	}
+	attrs, ok := s.attributes[p.CategoryID]
+	if ok {
+		given := map[int]trendyol.ProductAttribute{}
+		for _, a := range p.Attributes {
+			given[a.AttributeID] = a
+		}
+		for _, def := range attrs {
+			a, present := given[def.AttributeID]
+			if !present {
+				if def.Required {
+					reasons = append(reasons, fmt.Sprintf("required attribute %s (%d) is missing", def.AttributeName, def.AttributeID))
+				}
+				continue
+			}
+			if a.AttributeValueID != 0 {
+				found := false
+				for _, v := range def.AttributeValues {
+					if v.AttributeValueID == a.AttributeValueID {
+						found = true
+						break
+					}
+				}
+				if !found {
+					reasons = append(reasons, fmt.Sprintf("attribute value %d is not valid for attribute %d", a.AttributeValueID, def.AttributeID))
+				}
+			} else if a.CustomAttributeValue != "" && !def.AllowCustomValue {
+				reasons = append(reasons, fmt.Sprintf("attribute %d does not allow custom values", def.AttributeID))
+			}
+		}
+	}
+	return reasons
+}
+
+func (s *Server) listProducts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	var matched []trendyol.Product
+	for _, bc := range s.productKeys {
+		p := s.products[bc]
+		if !productMatches(p, q) {
+			continue
+		}
+		matched = append(matched, *p)
+	}
+	s.mu.Unlock()
+
+	content, meta := paginate(matched, queryInt(q, "page", 0), queryInt(q, "size", 50))
+	writeJSON(w, http.StatusOK, struct {
+		trendyol.PaginatedResponse
+		Content []trendyol.Product `json:"content"`
+	}{meta, content})
+}
+
+func productMatches(p *trendyol.Product, q map[string][]string) bool {
+	get := func(k string) string {
+		if v := q[k]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	boolFilter := func(k string, actual bool) bool {
+		v := get(k)
+		if v == "" {
+			return true
+		}
+		b, err := strconv.ParseBool(v)
+		return err != nil || b == actual
+	}
+	if v := q["barcode"]; len(v) > 0 {
+		found := false
+		for _, bc := range v {
+			for _, part := range strings.Split(bc, ",") {
+				if part == p.Barcode {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if v := get("stockCode"); v != "" && v != p.StockCode {
+		return false
+	}
+	if v := get("productMainId"); v != "" && v != p.ProductMainID {
+		return false
+	}
+	if !boolFilter("approved", p.Approved) || !boolFilter("archived", p.Archived) ||
+		!boolFilter("onSale", p.OnSale) || !boolFilter("rejected", p.Rejected) ||
+		!boolFilter("blacklisted", p.Blacklisted) {
+		return false
+	}
+	if v := q["brandIds"]; len(v) > 0 {
+		found := false
+		for _, id := range v {
+			if id == strconv.Itoa(p.BrandID) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	date := p.CreateDateTime
+	if get("dateQueryType") == "LAST_MODIFIED_DATE" {
+		date = p.LastUpdateDate
+	}
+	if v, err := strconv.ParseInt(get("startDate"), 10, 64); err == nil && date < v {
+		return false
+	}
+	if v, err := strconv.ParseInt(get("endDate"), 10, 64); err == nil && date > v {
+		return false
+	}
+	return true
+}
+
+func (s *Server) createProducts(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.CreateProductsRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if len(body.Items) == 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "items cannot be empty", "items")
+		return
+	}
+	if len(body.Items) > MaxProductCreateItems {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("items cannot contain more than %d products", MaxProductCreateItems), "items")
+		return
+	}
+
+	s.mu.Lock()
+	items := make([]trendyol.BatchResponseItem, len(body.Items))
+	seen := map[string]bool{}
+	for i, p := range body.Items {
+		reasons := s.validateProduct(p)
+		if _, exists := s.products[p.Barcode]; exists || seen[p.Barcode] {
+			reasons = append(reasons, "barcode "+p.Barcode+" already exists")
+		}
+		if p.ListPrice < p.SalePrice {
+			reasons = append(reasons, "listPrice must be greater than or equal to salePrice")
+		}
+		if p.Quantity > MaxQuantity {
+			reasons = append(reasons, fmt.Sprintf("quantity cannot be greater than %d", MaxQuantity))
+		}
+		seen[p.Barcode] = true
+		items[i] = batchItem(p, reasons)
+		if len(reasons) == 0 {
+			p.Approved = true
+			s.putProduct(p)
+		}
+	}
+	id := s.newBatch("ProductV2OnBoarding", items)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, trendyol.BatchResponse{BatchRequestID: id})
+}
+
+func (s *Server) updateProducts(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.UpdateProductsRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if len(body.Items) == 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "items cannot be empty", "items")
+		return
+	}
+	if len(body.Items) > MaxProductCreateItems {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("items cannot contain more than %d products", MaxProductCreateItems), "items")
+		return
+	}
+
+	s.mu.Lock()
+	items := make([]trendyol.BatchResponseItem, len(body.Items))
+	for i, p := range body.Items {
+		existing, ok := s.products[p.Barcode]
+		var reasons []string
+		if !ok {
+			reasons = append(reasons, "product not found with barcode "+p.Barcode)
+		} else {
+			reasons = s.validateProduct(p)
+			if existing.Locked {
+				reasons = append(reasons, "product is locked and cannot be updated")
+			}
+		}
+		items[i] = batchItem(p, reasons)
+		if len(reasons) == 0 {
+			// Content updates never change price or stock
+			p.Quantity = existing.Quantity
+			p.ListPrice = existing.ListPrice
+			p.SalePrice = existing.SalePrice
+			p.Approved = existing.Approved
+			p.Archived = existing.Archived
+			p.Brand = ""
+			p.CategoryName = ""
+			s.putProduct(p)
+		}
+	}
+	id := s.newBatch("ProductV2Update", items)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, trendyol.BatchResponse{BatchRequestID: id})
+}
+
+func (s *Server) deleteProducts(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Items []struct {
+			Barcode string `json:"barcode"`
+		} `json:"items"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	items := make([]trendyol.BatchResponseItem, len(body.Items))
+	for i, it := range body.Items {
+		var reasons []string
+		if _, ok := s.products[it.Barcode]; !ok {
+			reasons = append(reasons, "product not found with barcode "+it.Barcode)
+		} else {
+			delete(s.products, it.Barcode)
+			for j, bc := range s.productKeys {
+				if bc == it.Barcode {
+					s.productKeys = append(s.productKeys[:j], s.productKeys[j+1:]...)
+					break
+				}
+			}
+		}
+		items[i] = batchItem(it, reasons)
+	}
+	id := s.newBatch("ProductDelete", items)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, trendyol.BatchResponse{BatchRequestID: id})
+}
+
+func (s *Server) updateArchiveState(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Items []trendyol.ArchiveStateItem `json:"items"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if len(body.Items) == 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "items cannot be empty", "items")
+		return
+	}
+
+	s.mu.Lock()
+	items := make([]trendyol.BatchResponseItem, len(body.Items))
+	for i, it := range body.Items {
+		var reasons []string
+		if p, ok := s.products[it.Barcode]; !ok {
+			reasons = append(reasons, "product not found with barcode "+it.Barcode)
+		} else {
+			updated := *p
+			updated.Archived = it.Archived
+			s.putProduct(updated)
+		}
+		items[i] = batchItem(it, reasons)
+	}
+	id := s.newBatch("ProductArchiveUpdate", items)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, trendyol.BatchResponse{BatchRequestID: id})
+}
+
+func (s *Server) getBatchRequest(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("p1")
+
+	s.mu.Lock()
+	b, ok := s.batches[id]
+	if !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, "batch request not found: "+id)
+		return
+	}
+	b.polls++
+	resp := b.resp
+	if b.polls > s.batchPolls {
+		if b.resp.Status == trendyol.BatchStatusInProgress {
+			b.resp.Status = trendyol.BatchStatusCompleted
+			if b.final != "" {
+				b.resp.Status = b.final
+			}
+			b.resp.LastModification = s.nowMillis()
+		}
+		resp = b.resp
+	} else {
+		resp.Items = nil
+	}
+	s.mu.Unlock()
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, trendyol.ErrCodeInternal, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func (s *Server) updatePriceInventory(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.UpdatePriceInventoryRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if len(body.Items) == 0 {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "items cannot be empty", "items")
+		return
+	}
+	if len(body.Items) > MaxPriceInventoryItems {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, fmt.Sprintf("items cannot contain more than %d entries", MaxPriceInventoryItems), "items")
+		return
+	}
+
+	s.mu.Lock()
+	items := make([]trendyol.BatchResponseItem, len(body.Items))
+	for i, it := range body.Items {
+		var reasons []string
+		p, ok := s.products[it.Barcode]
+		switch {
+		case !ok:
+			reasons = append(reasons, "product not found with barcode "+it.Barcode)
+		case it.Quantity < 0 || it.Quantity > MaxQuantity:
+			reasons = append(reasons, fmt.Sprintf("quantity must be between 0 and %d", MaxQuantity))
+		case it.ListPrice < it.SalePrice:
+			reasons = append(reasons, "listPrice must be greater than or equal to salePrice")
+		}
+		items[i] = batchItem(it, reasons)
+		if len(reasons) == 0 {
+			updated := *p
+			updated.Quantity = it.Quantity
+			updated.SalePrice = it.SalePrice
+			updated.ListPrice = it.ListPrice
+			s.putProduct(updated)
+		}
+	}
+	id := s.newBatch("ProductInventoryUpdate", items)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, trendyol.BatchResponse{BatchRequestID: id})
+}
+
+func (s *Server) listBrands(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	brands := append([]trendyol.Brand(nil), s.brands...)
+	s.mu.Unlock()
+
+	content, _ := paginate(brands, queryInt(q, "page", 0), queryInt(q, "size", 1000))
+	writeJSON(w, http.StatusOK, map[string]interface{}{"brands": content})
+}
+
+func (s *Server) listCategories(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	categories := append([]trendyol.Category(nil), s.categories...)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"categories": categories})
+}
+
+func (s *Server) getCategoryAttributes(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathInt64(w, r, "p0")
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	attrs, found := s.attributes[int(id)]
+	var name string
+	for _, c := range s.categories {
+		if c.ID == int(id) {
+			name = c.Name
+			found = true
+		}
+	}
+	s.mu.Unlock()
+	if !found {
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, fmt.Sprintf("category not found: %d", id))
+		return
+	}
+
+	// Trendyol nests attribute and value data; mirror that shape
+	type idName struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	type categoryAttribute struct {
+		Attribute       idName   `json:"attribute"`
+		AttributeValues []idName `json:"attributeValues"`
+		Required        bool     `json:"required"`
+		AllowCustom     bool     `json:"allowCustom"`
+		Varianter       bool     `json:"varianter"`
+		Slicer          bool     `json:"slicer"`
+	}
+	out := make([]categoryAttribute, len(attrs))
+	for i, a := range attrs {
+		values := make([]idName, len(a.AttributeValues))
+		for j, v := range a.AttributeValues {
+			values[j] = idName{ID: v.AttributeValueID, Name: v.Value}
+		}
+		out[i] = categoryAttribute{
+			Attribute:       idName{ID: a.AttributeID, Name: a.AttributeName},
+			AttributeValues: values,
+			Required:        a.Required,
+			AllowCustom:     a.AllowCustomValue,
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":                 id,
+		"name":               name,
+		"displayName":        name,
+		"categoryAttributes": out,
+	})
+}
@@ -0,0 +1,474 @@
+// Package trendyoltest provides an in-process fake of the Trendyol Marketplace
+// API for offline testing.
+//
+// The fake registers a handler for every route in trendyol.DefaultEndpoints and
+// keeps products, batch requests, orders, claims, webhooks and finance records
+// in memory. Point a client at it with SetBaseURL, or let the server build one:
+//
+//	srv := trendyoltest.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	batch, err := client.Products.Create(ctx, []trendyol.Product{p})
+//
+// Batch requests report IN_PROGRESS for the first poll(s) and COMPLETED
+// afterwards, orders follow the Created -> Picking -> Invoiced flow and every
+// error is returned in the trendyol.Error JSON shape.
+package trendyoltest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+// Default credentials accepted by a server created without WithCredentials.
+const (
+	DefaultSellerID  = "123456"
+	DefaultAPIKey    = "test-api-key"
+	DefaultAPISecret = "test-api-secret"
+)
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithCredentials sets the seller ID and Basic auth credentials the server accepts
+func WithCredentials(sellerID, apiKey, apiSecret string) Option {
+	return func(s *Server) {
+		s.sellerID = sellerID
+		s.apiKey = apiKey
+		s.apiSecret = apiSecret
+	}
+}
+
+// WithBatchPolls sets how many GetBatchStatus polls report IN_PROGRESS before
+// a batch request is reported as COMPLETED. The default is 1.
+func WithBatchPolls(n int) Option {
+	return func(s *Server) {
+		s.batchPolls = n
+	}
+}
+
+// WithClock sets the time source used for creation and modification dates
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// Call records a single request handled by the server
+type Call struct {
+	EndpointKey string
+	Method      string
+	Path        string
+	Query       url.Values
+	Header      http.Header
+	Body        []byte
+}
+
+// Server is an in-memory Trendyol API emulator backed by httptest.Server
+type Server struct {
+	*httptest.Server
+
+	sellerID   string
+	apiKey     string
+	apiSecret  string
+	batchPolls int
+	now        func() time.Time
+
+	mu              sync.Mutex
+	seq             int64
+	calls           []Call
+	failures        map[string][]injectedFailure
+	products        map[string]*trendyol.Product
+	productKeys     []string
+	batches         map[string]*batchState
+	brands          []trendyol.Brand
+	categories      []trendyol.Category
+	attributes      map[int][]trendyol.CategoryAttribute
+	orders          []*trendyol.Order
+	invoices        map[int64]string
+	boxes           map[int64]int
+	laborCosts      map[int64][]trendyol.LaborCost
+	labels          map[string][]byte
+	claims          []*claimState
+	audits          map[int64][]ClaimAudit
+	webhooks        []*trendyol.Webhook
+	settlements     []trendyol.Settlement
+	otherFinancials []trendyol.OtherFinancial
+	cargo           map[string][]trendyol.CargoInvoiceDetail
+	addresses       []trendyol.Address
+}
+
+type injectedFailure struct {
+	status  int
+	code    string
+	message string
+	header  http.Header
+}
+
+type route struct {
+	method  string
+	seller  bool
+	handler http.HandlerFunc
+}
+
+// NewServer starts a fake Trendyol API server. Callers must Close it.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		sellerID:   DefaultSellerID,
+		apiKey:     DefaultAPIKey,
+		apiSecret:  DefaultAPISecret,
+		batchPolls: 1,
+		now:        time.Now,
+		failures:   map[string][]injectedFailure{},
+		products:   map[string]*trendyol.Product{},
+		batches:    map[string]*batchState{},
+		attributes: map[int][]trendyol.CategoryAttribute{},
+		invoices:   map[int64]string{},
+		boxes:      map[int64]int{},
+		laborCosts: map[int64][]trendyol.LaborCost{},
+		labels:     map[string][]byte{},
+		audits:     map[int64][]ClaimAudit{},
+		cargo:      map[string][]trendyol.CargoInvoiceDetail{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.seedFixtures()
+
+	mux := http.NewServeMux()
+	endpoints := trendyol.DefaultEndpoints()
+	for key, rt := range s.routes() {
+		tmpl, ok := endpoints[key]
+		if !ok {
+			continue
+		}
+		mux.HandleFunc(rt.method+" "+pattern(tmpl), s.wrap(key, rt))
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, "no route for "+r.Method+" "+r.URL.Path)
+	})
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// SellerID returns the seller ID the server accepts
+func (s *Server) SellerID() string {
+	return s.sellerID
+}
+
+// Client returns a trendyol.Client configured with the server's credentials
+// and base URL. Retries are disabled unless opts override them.
+func (s *Server) Client(opts ...trendyol.ClientOption) *trendyol.Client {
+	opts = append([]trendyol.ClientOption{
+		trendyol.WithRetryConfig(0, time.Millisecond),
+		trendyol.WithRateLimit(6000),
+	}, opts...)
+	c := trendyol.NewClient(s.sellerID, s.apiKey, s.apiSecret, false, opts...)
+	c.SetBaseURL(s.URL)
+	return c
+}
+
+// FailNext makes the next count requests to the endpoint identified by key fail
+// with the given HTTP status and error code before reaching the handler.
+func (s *Server) FailNext(key string, count, status int, code, message string) {
+	s.FailNextWithHeader(key, count, status, code, message, nil)
+}
+
+// FailNextWithHeader is like FailNext but also sets the given response headers
+func (s *Server) FailNextWithHeader(key string, count, status int, code, message string, header http.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < count; i++ {
+		s.failures[key] = append(s.failures[key], injectedFailure{status: status, code: code, message: message, header: header})
+	}
+}
+
+// Calls returns the requests handled so far, optionally filtered by endpoint keys
+func (s *Server) Calls(keys ...string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(keys) == 0 {
+		return append([]Call(nil), s.calls...)
+	}
+	var out []Call
+	for _, c := range s.calls {
+		for _, k := range keys {
+			if c.EndpointKey == k {
+				out = append(out, c)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (s *Server) routes() map[string]route {
+	return map[string]route{
+		// Product Module
+		trendyol.EndpointGetProductsKey:           {http.MethodGet, true, s.listProducts},
+		trendyol.EndpointCreateProductsKey:        {http.MethodPost, true, s.createProducts},
+		trendyol.EndpointUpdateProductsKey:        {http.MethodPut, true, s.updateProducts},
+		trendyol.EndpointDeleteProductsKey:        {http.MethodDelete, true, s.deleteProducts},
+		trendyol.EndpointUpdateArchiveStateKey:    {http.MethodPut, true, s.updateArchiveState},
+		trendyol.EndpointGetBatchRequestResultKey: {http.MethodGet, true, s.getBatchRequest},
+		trendyol.EndpointGetBrandsKey:             {http.MethodGet, false, s.listBrands},
+		trendyol.EndpointGetCategoriesKey:         {http.MethodGet, false, s.listCategories},
+		trendyol.EndpointGetCategoryAttributesKey: {http.MethodGet, false, s.getCategoryAttributes},
+
+		// Inventory Module
+		trendyol.EndpointUpdatePriceInventoryKey: {http.MethodPost, true, s.updatePriceInventory},
+
+		// Order Module
+		trendyol.EndpointGetOrdersKey:            {http.MethodGet, true, s.listOrders},
+		trendyol.EndpointUpdatePackageStatusKey:  {http.MethodPut, true, s.updatePackageStatus},
+		trendyol.EndpointUpdateTrackingNumberKey: {http.MethodPut, true, s.updateTrackingNumber},
+		trendyol.EndpointCancelPackageItemsKey:   {http.MethodPut, true, s.cancelPackageItems},
+		trendyol.EndpointSplitPackageKey:         {http.MethodPost, true, s.splitPackage},
+		trendyol.EndpointMultiSplitPackageKey:    {http.MethodPost, true, s.multiSplitPackage},
+		trendyol.EndpointQuantitySplitPackageKey: {http.MethodPost, true, s.quantitySplitPackage},
+		trendyol.EndpointUpdateBoxInfoKey:        {http.MethodPut, true, s.updateBoxInfo},
+		trendyol.EndpointAlternativeDeliveryKey:  {http.MethodPut, true, s.alternativeDelivery},
+		trendyol.EndpointManualDeliverKey:        {http.MethodPut, true, s.manualDeliver},
+		trendyol.EndpointManualReturnKey:         {http.MethodPut, true, s.manualReturn},
+		trendyol.EndpointUpdateCargoProviderKey:  {http.MethodPut, true, s.updateCargoProvider},
+		trendyol.EndpointUpdateWarehouseKey:      {http.MethodPut, true, s.updateWarehouse},
+		trendyol.EndpointExtendDeliveryDateKey:   {http.MethodPut, true, s.extendDeliveryDate},
+		trendyol.EndpointUpdateLaborCostsKey:     {http.MethodPut, true, s.updateLaborCosts},
+		trendyol.EndpointDeliveredByServiceKey:   {http.MethodPut, true, s.deliveredByService},
+
+		// Claims Module
+		trendyol.EndpointGetClaimsKey:            {http.MethodGet, true, s.listClaims},
+		trendyol.EndpointApproveClaimKey:         {http.MethodPut, true, s.approveClaim},
+		trendyol.EndpointRejectClaimKey:          {http.MethodPost, true, s.rejectClaim},
+		trendyol.EndpointGetClaimIssueReasonsKey: {http.MethodGet, false, s.listClaimReasons},
+		trendyol.EndpointGetClaimAuditKey:        {http.MethodGet, true, s.getClaimAudit},
+
+		// Address Module
+		trendyol.EndpointSellerAddressesKey: {http.MethodGet, true, s.listAddresses},
+
+		// Invoice Module
+		trendyol.EndpointSendInvoiceLinkKey:   {http.MethodPost, true, s.sendInvoiceLink},
+		trendyol.EndpointDeleteInvoiceLinkKey: {http.MethodPost, true, s.deleteInvoiceLink},
+
+		// Common Label Module
+		trendyol.EndpointCreateCommonLabelKey: {http.MethodPost, true, s.createCommonLabel},
+		trendyol.EndpointGetCommonLabelKey:    {http.MethodGet, true, s.getCommonLabel},
+
+		// Finance Module
+		trendyol.EndpointGetSettlementsKey:         {http.MethodGet, true, s.listSettlements},
+		trendyol.EndpointGetCargoInvoiceDetailsKey: {http.MethodGet, true, s.getCargoInvoiceDetails},
+		trendyol.EndpointGetOtherFinancialsKey:     {http.MethodGet, true, s.listOtherFinancials},
+
+		// Member Module
+		trendyol.EndpointGetCountriesKey:      {http.MethodGet, false, s.listCountries},
+		trendyol.EndpointGetCountryCitiesKey:  {http.MethodGet, false, s.listCountryCities},
+		trendyol.EndpointGetDomesticCitiesKey: {http.MethodGet, false, s.listCountryCities},
+
+		// Test Module
+		trendyol.EndpointCreateTestOrderKey:          {http.MethodPost, false, s.createTestOrder},
+		trendyol.EndpointUpdateTestOrderStatusKey:    {http.MethodPut, true, s.updateTestOrderStatus},
+		trendyol.EndpointTestClaimWaitingInActionKey: {http.MethodPut, true, s.claimWaitingInAction},
+
+		// Shipment Module
+		trendyol.EndpointGetShipmentProvidersKey: {http.MethodGet, false, s.listShipmentProviders},
+
+		// Webhook Module
+		trendyol.EndpointCreateWebhookKey:     {http.MethodPost, true, s.createWebhook},
+		trendyol.EndpointListWebhooksKey:      {http.MethodGet, true, s.listWebhooks},
+		trendyol.EndpointUpdateWebhookKey:     {http.MethodPut, true, s.updateWebhook},
+		trendyol.EndpointDeleteWebhookKey:     {http.MethodDelete, true, s.deleteWebhook},
+		trendyol.EndpointActivateWebhookKey:   {http.MethodPut, true, s.activateWebhook},
+		trendyol.EndpointDeactivateWebhookKey: {http.MethodPut, true, s.deactivateWebhook},
+	}
+}
+
+// wrap applies call recording, request IDs, injected failures, authentication
+// and seller checks before dispatching to the route handler.
+func (s *Server) wrap(key string, rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "unreadable request body")
+			return
+		}
+
+		s.mu.Lock()
+		s.calls = append(s.calls, Call{
+			EndpointKey: key,
+			Method:      r.Method,
+			Path:        r.URL.Path,
+			Query:       r.URL.Query(),
+			Header:      r.Header.Clone(),
+			Body:        body,
+		})
+		w.Header().Set("X-Request-Id", "req-"+strconv.Itoa(len(s.calls)))
+		var injected *injectedFailure
+		if queue := s.failures[key]; len(queue) > 0 {
+			injected = &queue[0]
+			s.failures[key] = queue[1:]
+		}
+		s.mu.Unlock()
+
+		if injected != nil {
+			for k, vs := range injected.header {
+				for _, v := range vs {
+					w.Header().Add(k, v)
+				}
+			}
+			writeError(w, injected.status, injected.code, injected.message)
+			return
+		}
+
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != s.apiKey || pass != s.apiSecret {
+			writeError(w, http.StatusUnauthorized, trendyol.ErrCodeAuthentication, "invalid credentials")
+			return
+		}
+		if rt.seller && r.PathValue("p0") != s.sellerID {
+			writeError(w, http.StatusForbidden, trendyol.ErrCodeAuthentication, "seller "+r.PathValue("p0")+" is not accessible with these credentials")
+			return
+		}
+
+		rt.handler(w, r)
+	}
+}
+
+// pattern converts an endpoint template such as "/sellers/%s/items/%d" into a
+// ServeMux pattern with positional wildcards ("/sellers/{p0}/items/{p1}").
+func pattern(tmpl string) string {
+	var b strings.Builder
+	n := 0
+	for i := 0; i < len(tmpl); i++ {
+		if tmpl[i] == '%' && i+1 < len(tmpl) {
+			fmt.Fprintf(&b, "{p%d}", n)
+			n++
+			i++
+			continue
+		}
+		b.WriteByte(tmpl[i])
+	}
+	return b.String()
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	data, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string, fields ...string) {
+	item := trendyol.ErrorItem{Code: code, Message: message}
+	if len(fields) > 0 {
+		item.Field = fields[0]
+	}
+	writeJSON(w, status, &trendyol.Error{
+		StatusCode: status,
+		Status:     strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_")),
+		Message:    message,
+		Errors:     []trendyol.ErrorItem{item},
+	})
+}
+
+func pathInt64(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	v, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "invalid path parameter: "+r.PathValue(name))
+		return 0, false
+	}
+	return v, true
+}
+
+func queryInt(q url.Values, key string, def int) int {
+	if v, err := strconv.Atoi(q.Get(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+func queryMillis(q url.Values, key string) (int64, bool) {
+	v, err := strconv.ParseInt(q.Get(key), 10, 64)
+	return v, err == nil
+}
+
+// paginate slices items according to page/size and returns the page metadata
+func paginate[T any](items []T, page, size int) ([]T, trendyol.PaginatedResponse) {
+	if size <= 0 {
+		size = 50
+	}
+	if page < 0 {
+		page = 0
+	}
+	meta := trendyol.PaginatedResponse{
+		Page:         page,
+		Size:         size,
+		TotalElement: len(items),
+		TotalPages:   (len(items) + size - 1) / size,
+	}
+	start := page * size
+	if start >= len(items) {
+		return []T{}, meta
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end], meta
+}
+
+// nowMillis returns the server clock in Unix milliseconds; callers hold s.mu
+func (s *Server) nowMillis() int64 {
+	return s.now().UnixMilli()
+}
+
+// nextID returns a monotonically increasing numeric identifier; callers hold s.mu
+func (s *Server) nextID() int64 {
+	s.seq++
+	return s.seq
+}
+
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
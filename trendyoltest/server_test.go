@@ -0,0 +1,170 @@
+package trendyoltest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+func testProduct(barcode string) trendyol.Product {
+	return trendyol.Product{
+		Barcode:       barcode,
+		Title:         "Pamuk Hoodie",
+		ProductMainID: "HOOD-" + barcode,
+		BrandID:       trendyoltest.FixtureBrandID,
+		CategoryID:    trendyoltest.FixtureCategoryID,
+		Quantity:      5,
+		StockCode:     "STK-" + barcode,
+		ListPrice:     249.90,
+		SalePrice:     149.90,
+		CurrencyType:  "TRY",
+		VATRate:       20,
+		Images:        []trendyol.ProductImage{{URL: "https://example.com/img.jpg"}},
+		Attributes: []trendyol.ProductAttribute{
+			{AttributeID: trendyoltest.FixtureOriginAttribute, AttributeValueID: trendyoltest.FixtureOriginTR},
+		},
+	}
+}
+
+// TestProductBatchLifecycle ürün oluşturma batch'inin IN_PROGRESS -> COMPLETED geçişini doğrular.
+func TestProductBatchLifecycle(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	invalid := testProduct("BAD-1")
+	invalid.Attributes = nil
+	batch, err := client.Products.Create(ctx, []trendyol.Product{testProduct("ABC-1"), invalid})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	status, err := client.Products.GetBatchStatus(ctx, batch.BatchRequestID)
+	if err != nil {
+		t.Fatalf("GetBatchStatus: %v", err)
+	}
+	if status.Status != trendyol.BatchStatusInProgress {
+		t.Fatalf("ilk sorguda IN_PROGRESS beklendi, gelen %s", status.Status)
+	}
+
+	status, err = client.Products.GetBatchStatus(ctx, batch.BatchRequestID)
+	if err != nil {
+		t.Fatalf("GetBatchStatus: %v", err)
+	}
+	if status.Status != trendyol.BatchStatusCompleted {
+		t.Fatalf("ikinci sorguda COMPLETED beklendi, gelen %s", status.Status)
+	}
+	if status.ItemCount != 2 || status.FailedItemCount != 1 || len(status.Items) != 2 {
+		t.Fatalf("beklenmeyen batch özeti: %+v", status)
+	}
+	if status.Items[1].Status != trendyol.BatchItemStatusFailed || len(status.Items[1].FailureReasons) == 0 {
+		t.Fatalf("eksik özellikli ürün başarısız olmalıydı: %+v", status.Items[1])
+	}
+
+	p, err := client.Products.GetByBarcode(ctx, "ABC-1")
+	if err != nil {
+		t.Fatalf("GetByBarcode: %v", err)
+	}
+	if p.Brand == "" || !p.OnSale {
+		t.Errorf("sunucu tarafından doldurulan alanlar eksik: %+v", p)
+	}
+	if _, ok := srv.Product("BAD-1"); ok {
+		t.Error("başarısız ürün kaydedilmemeliydi")
+	}
+}
+
+// TestPriceInventoryUpdate stok/fiyat güncellemesinin ürüne yansıdığını doğrular.
+func TestPriceInventoryUpdate(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	srv.AddProduct(testProduct("ABC-1"))
+	client := srv.Client()
+
+	_, err := client.PriceInventory.Update(context.Background(), []trendyol.PriceInventoryItem{
+		{Barcode: "ABC-1", Quantity: 42, SalePrice: 100, ListPrice: 120},
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	p, _ := srv.Product("ABC-1")
+	if p.Quantity != 42 || p.SalePrice != 100 || p.ListPrice != 120 {
+		t.Errorf("stok/fiyat güncellenmedi: %+v", p)
+	}
+}
+
+// TestOrderStatusFlow sipariş paketinin Created -> Picking -> Invoiced akışını ve
+// geçersiz geçişlerin reddedildiğini doğrular.
+func TestOrderStatusFlow(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	order := srv.AddOrder(trendyol.Order{
+		Lines: []trendyol.OrderLine{{Barcode: "ABC-1", Quantity: 2, Amount: 100, Price: 100}},
+	})
+	lines := []trendyol.UpdatePackageStatusLine{{LineID: order.Lines[0].ID, Quantity: 2}}
+
+	err := client.Orders.UpdateStatus(ctx, order.ID, trendyol.UpdatePackageStatusRequest{
+		Status: trendyol.StatusInvoiced,
+		Lines:  lines,
+		Params: map[string]string{"invoiceNumber": "INV-1"},
+	})
+	var apiErr *trendyol.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Picking olmadan Invoiced reddedilmeliydi, gelen: %v", err)
+	}
+	if len(apiErr.Errors) == 0 || apiErr.Errors[0].Code != trendyol.ErrCodeValidation {
+		t.Errorf("hata Error/ErrorItem formatında dönmeli: %+v", apiErr)
+	}
+
+	if err := client.Orders.UpdateStatus(ctx, order.ID, trendyol.UpdatePackageStatusRequest{Status: trendyol.StatusPicking, Lines: lines}); err != nil {
+		t.Fatalf("Picking: %v", err)
+	}
+	if err := client.Orders.UpdateStatus(ctx, order.ID, trendyol.UpdatePackageStatusRequest{
+		Status: trendyol.StatusInvoiced,
+		Lines:  lines,
+		Params: map[string]string{"invoiceNumber": "INV-1"},
+	}); err != nil {
+		t.Fatalf("Invoiced: %v", err)
+	}
+
+	orders, _, err := client.Orders.List(ctx, trendyol.ListOrdersOptions{Status: trendyol.StatusInvoiced, Size: 10})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(orders) != 1 || len(orders[0].PackageHistories) != 3 {
+		t.Fatalf("Invoiced paket ve 3 geçmiş kaydı beklendi: %+v", orders)
+	}
+}
+
+// TestAuthAndInjectedFailures kimlik doğrulama ve enjekte edilen hataları doğrular.
+func TestAuthAndInjectedFailures(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	bad := trendyol.NewClient(srv.SellerID(), "wrong", "creds", false, trendyol.WithRetryConfig(0, 0))
+	bad.SetBaseURL(srv.URL)
+	var apiErr *trendyol.Error
+	if err := bad.TestAuthentication(ctx); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("401 beklendi, gelen: %v", err)
+	}
+
+	srv.FailNext(trendyol.EndpointListWebhooksKey, 1, http.StatusTooManyRequests, trendyol.ErrCodeRateLimit, "slow down")
+	client := srv.Client()
+	if _, err := client.Webhooks.List(ctx); err == nil {
+		t.Fatal("enjekte edilen 429 hatası dönmeliydi")
+	}
+	if _, err := client.Webhooks.List(ctx); err != nil {
+		t.Fatalf("ikinci çağrı başarılı olmalıydı: %v", err)
+	}
+	if n := len(srv.Calls(trendyol.EndpointListWebhooksKey)); n != 2 {
+		t.Errorf("2 çağrı kaydı beklendi, gelen %d", n)
+	}
+}
@@ -0,0 +1,172 @@
+package trendyoltest
+
+import (
+	"net/http"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+// MaxWebhooks is the number of subscriptions a seller may hold, active or not
+const MaxWebhooks = 15
+
+// Webhooks returns the stored webhook subscriptions
+func (s *Server) Webhooks() []trendyol.Webhook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]trendyol.Webhook, len(s.webhooks))
+	for i, wh := range s.webhooks {
+		out[i] = *wh
+	}
+	return out
+}
+
+// validateWebhookAuth checks the credentials required by the authentication type
+func validateWebhookAuth(w http.ResponseWriter, authType, username, password, apiKey string) bool {
+	switch authType {
+	case "BASIC_AUTHENTICATION":
+		if username == "" || password == "" {
+			writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "username and password are required for BASIC_AUTHENTICATION", "username")
+			return false
+		}
+	case "API_KEY":
+		if apiKey == "" {
+			writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "apiKey is required for API_KEY authentication", "apiKey")
+			return false
+		}
+	default:
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "authenticationType must be BASIC_AUTHENTICATION or API_KEY", "authenticationType")
+		return false
+	}
+	return true
+}
+
+// findWebhook returns the subscription with the ID in path parameter p1; callers hold s.mu
+func (s *Server) findWebhook(w http.ResponseWriter, r *http.Request) *trendyol.Webhook {
+	id := r.PathValue("p1")
+	for _, wh := range s.webhooks {
+		if wh.ID == id {
+			return wh
+		}
+	}
+	writeError(w, http.StatusNotFound, trendyol.ErrCodeNotFound, "webhook not found: "+id)
+	return nil
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.CreateWebhookRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.URL == "" {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "url is required", "url")
+		return
+	}
+	if !validateWebhookAuth(w, body.AuthenticationType, body.Username, body.Password, body.APIKey) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.webhooks) >= MaxWebhooks {
+		writeError(w, http.StatusBadRequest, trendyol.ErrCodeValidation, "a seller can have at most 15 webhooks")
+		return
+	}
+	for _, wh := range s.webhooks {
+		if wh.URL == body.URL {
+			writeError(w, http.StatusConflict, trendyol.ErrCodeValidation, "a webhook with this url already exists", "url")
+			return
+		}
+	}
+	wh := &trendyol.Webhook{
+		ID:                 newUUID(),
+		URL:                body.URL,
+		Username:           body.Username,
+		Password:           body.Password,
+		AuthenticationType: body.AuthenticationType,
+		APIKey:             body.APIKey,
+		SubscribedStatuses: append([]string(nil), body.SubscribedStatuses...),
+		Active:             true,
+	}
+	s.webhooks = append(s.webhooks, wh)
+	writeJSON(w, http.StatusOK, map[string]string{"id": wh.ID})
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Webhooks())
+}
+
+func (s *Server) updateWebhook(w http.ResponseWriter, r *http.Request) {
+	var body trendyol.UpdateWebhookRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wh := s.findWebhook(w, r)
+	if wh == nil {
+		return
+	}
+	updated := *wh
+	if body.URL != "" {
+		updated.URL = body.URL
+	}
+	if body.AuthenticationType != "" {
+		updated.AuthenticationType = body.AuthenticationType
+	}
+	if body.Username != "" {
+		updated.Username = body.Username
+	}
+	if body.Password != "" {
+		updated.Password = body.Password
+	}
+	if body.APIKey != "" {
+		updated.APIKey = body.APIKey
+	}
+	if body.SubscribedStatuses != nil {
+		updated.SubscribedStatuses = append([]string(nil), body.SubscribedStatuses...)
+	}
+	if body.Active != nil {
+		updated.Active = *body.Active
+	}
+	if !validateWebhookAuth(w, updated.AuthenticationType, updated.Username, updated.Password, updated.APIKey) {
+		return
+	}
+	*wh = updated
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wh := s.findWebhook(w, r)
+	if wh == nil {
+		return
+	}
+	for i, existing := range s.webhooks {
+		if existing == wh {
+			s.webhooks = append(s.webhooks[:i], s.webhooks[i+1:]...)
+			break
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) activateWebhook(w http.ResponseWriter, r *http.Request) {
+	s.setWebhookActive(w, r, true)
+}
+
+func (s *Server) deactivateWebhook(w http.ResponseWriter, r *http.Request) {
+	s.setWebhookActive(w, r, false)
+}
+
+func (s *Server) setWebhookActive(w http.ResponseWriter, r *http.Request, active bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wh := s.findWebhook(w, r)
+	if wh == nil {
+		return
+	}
+	wh.Active = active
+	w.WriteHeader(http.StatusOK)
+}
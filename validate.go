@@ -0,0 +1,237 @@
+package trendyol
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Validation issue codes
+const (
+	IssueMissingAttribute      = "MISSING_REQUIRED_ATTRIBUTE"
+	IssueInvalidAttributeValue = "INVALID_ATTRIBUTE_VALUE"
+	IssueCustomValueNotAllowed = "CUSTOM_VALUE_NOT_ALLOWED"
+	IssueEmptyAttributeValue   = "EMPTY_ATTRIBUTE_VALUE"
+	IssueUnknownAttribute      = "UNKNOWN_ATTRIBUTE"
+	IssueTitleLength           = "TITLE_LENGTH"
+	IssueDescriptionLength     = "DESCRIPTION_LENGTH"
+	IssueVATRate               = "INVALID_VAT_RATE"
+	IssueImageCount            = "IMAGE_COUNT"
+	IssuePrice                 = "LIST_PRICE_BELOW_SALE_PRICE"
+)
+
+// ValidationRules holds the local limits checked by ProductValidator
+type ValidationRules struct {
+	MaxTitleLength       int
+	MaxDescriptionLength int
+	VATRates             []int
+	MinImages            int
+	MaxImages            int
+}
+
+// DefaultValidationRules mirrors the limits documented by Trendyol for createProduct V2
+var DefaultValidationRules = ValidationRules{
+	MaxTitleLength:       100,
+	MaxDescriptionLength: 30000,
+	VATRates:             []int{0, 1, 10, 20},
+	MinImages:            1,
+	MaxImages:            8,
+}
+
+// ValidationIssue describes a single problem found in a product
+type ValidationIssue struct {
+	Barcode     string
+	Code        string
+	Field       string
+	AttributeID int
+	Message     string
+}
+
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("%s: %s (field: %s)", i.Code, i.Message, i.Field)
+}
+
+// ValidatorOption configures a ProductValidator
+type ValidatorOption func(*ProductValidator)
+
+// WithValidationRules replaces the default local limits
+func WithValidationRules(rules ValidationRules) ValidatorOption {
+	return func(v *ProductValidator) {
+		v.rules = rules
+	}
+}
+
+// ProductValidator checks products against their category attributes and
+// local content rules before they are submitted. Category attributes are
+// fetched once per category and cached for the lifetime of the validator.
+type ProductValidator struct {
+	categories CategoryService
+	rules      ValidationRules
+
+	mu    sync.Mutex
+	cache map[int][]CategoryAttribute
+}
+
+// NewProductValidator creates a validator that looks up attributes through categories
+func NewProductValidator(categories CategoryService, opts ...ValidatorOption) *ProductValidator {
+	v := &ProductValidator{
+		categories: categories,
+		rules:      DefaultValidationRules,
+		cache:      map[int][]CategoryAttribute{},
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// ClearCache drops all cached category attributes
+func (v *ProductValidator) ClearCache() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.cache = map[int][]CategoryAttribute{}
+}
+
+func (v *ProductValidator) attributes(ctx context.Context, categoryID int) ([]CategoryAttribute, error) {
+	v.mu.Lock()
+	attrs, ok := v.cache[categoryID]
+	v.mu.Unlock()
+	if ok {
+		return attrs, nil
+	}
+
+	attrs, err := v.categories.GetCategoryAttributes(ctx, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attributes of category %d: %w", categoryID, err)
+	}
+
+	v.mu.Lock()
+	v.cache[categoryID] = attrs
+	v.mu.Unlock()
+	return attrs, nil
+}
+
+// Validate returns the issues found in p. The error is only set when the
+// category attributes could not be fetched.
+func (v *ProductValidator) Validate(ctx context.Context, p Product) ([]ValidationIssue, error) {
+	issues := v.validateLocal(p)
+
+	if p.CategoryID == 0 {
+		return issues, nil
+	}
+	attrs, err := v.attributes(ctx, p.CategoryID)
+	if err != nil {
+		return issues, err
+	}
+	return append(issues, validateAttributes(p, attrs)...), nil
+}
+
+// ValidateAll validates every product and returns the issues keyed by barcode.
+// Products without issues are omitted.
+func (v *ProductValidator) ValidateAll(ctx context.Context, products []Product) (map[string][]ValidationIssue, error) {
+	out := map[string][]ValidationIssue{}
+	for _, p := range products {
+		issues, err := v.Validate(ctx, p)
+		if err != nil {
+			return out, err
+		}
+		if len(issues) > 0 {
+			out[p.Barcode] = append(out[p.Barcode], issues...)
+		}
+	}
+	return out, nil
+}
+
+func (v *ProductValidator) validateLocal(p Product) []ValidationIssue {
+	var issues []ValidationIssue
+	add := func(code, field, msg string) {
+		issues = append(issues, ValidationIssue{Barcode: p.Barcode, Code: code, Field: field, Message: msg})
+	}
+	r := v.rules
+
+	if n := utf8.RuneCountInString(strings.TrimSpace(p.Title)); n == 0 || (r.MaxTitleLength > 0 && n > r.MaxTitleLength) {
+		add(IssueTitleLength, "title", fmt.Sprintf("title must be between 1 and %d characters, got %d", r.MaxTitleLength, n))
+	}
+	if n := utf8.RuneCountInString(p.Description); r.MaxDescriptionLength > 0 && n > r.MaxDescriptionLength {
+		add(IssueDescriptionLength, "description", fmt.Sprintf("description cannot be longer than %d characters, got %d", r.MaxDescriptionLength, n))
+	}
+	if len(r.VATRates) > 0 {
+		valid := false
+		for _, rate := range r.VATRates {
+			if rate == p.VATRate {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			add(IssueVATRate, "vatRate", fmt.Sprintf("vat rate %d is not one of %v", p.VATRate, r.VATRates))
+		}
+	}
+	if n := len(p.Images); n < r.MinImages || (r.MaxImages > 0 && n > r.MaxImages) {
+		add(IssueImageCount, "images", fmt.Sprintf("image count must be between %d and %d, got %d", r.MinImages, r.MaxImages, n))
+	}
+	if p.ListPrice < p.SalePrice {
+		add(IssuePrice, "listPrice", fmt.Sprintf("listPrice %.2f is lower than salePrice %.2f", p.ListPrice, p.SalePrice))
+	}
+	return issues
+}
+
+func validateAttributes(p Product, attrs []CategoryAttribute) []ValidationIssue {
+	var issues []ValidationIssue
+	add := func(code string, attrID int, msg string) {
+		issues = append(issues, ValidationIssue{Barcode: p.Barcode, Code: code, Field: "attributes", AttributeID: attrID, Message: msg})
+	}
+
+	defs := make(map[int]CategoryAttribute, len(attrs))
+	for _, a := range attrs {
+		defs[a.AttributeID] = a
+	}
+
+	given := map[int]bool{}
+	for _, a := range p.Attributes {
+		given[a.AttributeID] = true
+		def, ok := defs[a.AttributeID]
+		if !ok {
+			add(IssueUnknownAttribute, a.AttributeID, fmt.Sprintf("attribute %d does not belong to category %d", a.AttributeID, p.CategoryID))
+			continue
+		}
+
+		switch {
+		case a.AttributeValueID != 0:
+			if len(def.AttributeValues) == 0 {
+				continue
+			}
+			found := false
+			for _, val := range def.AttributeValues {
+				if val.AttributeValueID == a.AttributeValueID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				add(IssueInvalidAttributeValue, a.AttributeID, fmt.Sprintf("value %d is not allowed for attribute %s (%d)", a.AttributeValueID, def.AttributeName, a.AttributeID))
+			}
+		case a.CustomAttributeValue != "":
+			if !def.AllowCustomValue {
+				add(IssueCustomValueNotAllowed, a.AttributeID, fmt.Sprintf("attribute %s (%d) does not accept custom values", def.AttributeName, a.AttributeID))
+			}
+		default:
+			add(IssueEmptyAttributeValue, a.AttributeID, fmt.Sprintf("attribute %s (%d) has no value", def.AttributeName, a.AttributeID))
+		}
+	}
+
+	var missing []CategoryAttribute
+	for _, def := range attrs {
+		if def.Required && !given[def.AttributeID] {
+			missing = append(missing, def)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i].AttributeID < missing[j].AttributeID })
+	for _, def := range missing {
+		add(IssueMissingAttribute, def.AttributeID, fmt.Sprintf("required attribute %s (%d) is missing", def.AttributeName, def.AttributeID))
+	}
+	return issues
+}
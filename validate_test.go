@@ -0,0 +1,81 @@
+package trendyol_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+func issueCodes(issues []trendyol.ValidationIssue) map[string]bool {
+	codes := map[string]bool{}
+	for _, i := range issues {
+		codes[i.Code] = true
+	}
+	return codes
+}
+
+// TestProductValidator kategori özelliklerine ve yerel kurallara göre doğrulamayı ve
+// kategori özelliklerinin önbelleğe alındığını doğrular.
+func TestProductValidator(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	v := trendyol.NewProductValidator(client.Categories)
+	ctx := context.Background()
+
+	issues, err := v.Validate(ctx, fixtureProduct("OK-1"))
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("geçerli ürün için hata beklenmedi: %v", issues)
+	}
+
+	bad := fixtureProduct("BAD-1")
+	bad.VATRate = 18
+	bad.ListPrice = 10
+	bad.Images = nil
+	bad.Attributes = []trendyol.ProductAttribute{
+		{AttributeID: trendyoltest.FixtureColorAttribute, AttributeValueID: 99999},
+		{AttributeID: 777, AttributeValueID: 1},
+	}
+	issues, err = v.Validate(ctx, bad)
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	codes := issueCodes(issues)
+	for _, want := range []string{
+		trendyol.IssueVATRate, trendyol.IssuePrice, trendyol.IssueImageCount,
+		trendyol.IssueInvalidAttributeValue, trendyol.IssueUnknownAttribute, trendyol.IssueMissingAttribute,
+	} {
+		if !codes[want] {
+			t.Errorf("%s hatası beklendi: %v", want, issues)
+		}
+	}
+
+	if n := len(srv.Calls(trendyol.EndpointGetCategoryAttributesKey)); n != 1 {
+		t.Errorf("kategori özellikleri bir kez çekilmeliydi, gelen %d", n)
+	}
+}
+
+// TestProductValidatorCustomValue AllowCustomValue=false olan özelliğe serbest değer girilmesini yakalar.
+func TestProductValidatorCustomValue(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	v := trendyol.NewProductValidator(srv.Client().Categories)
+
+	p := fixtureProduct("C-1")
+	p.Attributes = []trendyol.ProductAttribute{
+		{AttributeID: trendyoltest.FixtureOriginAttribute, CustomAttributeValue: "Mars"},
+		{AttributeID: trendyoltest.FixtureColorAttribute, CustomAttributeValue: "Füme"},
+	}
+	issues, err := v.Validate(context.Background(), p)
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Code != trendyol.IssueCustomValueNotAllowed || issues[0].AttributeID != trendyoltest.FixtureOriginAttribute {
+		t.Fatalf("yalnızca Menşei için CUSTOM_VALUE_NOT_ALLOWED beklendi: %v", issues)
+	}
+}
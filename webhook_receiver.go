@@ -0,0 +1,297 @@
+package trendyol
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Webhook authentication types
+const (
+	WebhookAuthBasic  = "BASIC_AUTHENTICATION"
+	WebhookAuthAPIKey = "API_KEY"
+)
+
+// Webhook subscription statuses used in SubscribedStatuses
+const (
+	WebhookStatusCreated           = "CREATED"
+	WebhookStatusPicking           = "PICKING"
+	WebhookStatusInvoiced          = "INVOICED"
+	WebhookStatusShipped           = "SHIPPED"
+	WebhookStatusCancelled         = "CANCELLED"
+	WebhookStatusDelivered         = "DELIVERED"
+	WebhookStatusUndelivered       = "UNDELIVERED"
+	WebhookStatusReturned          = "RETURNED"
+	WebhookStatusUnsupplied        = "UNSUPPLIED"
+	WebhookStatusAwaiting          = "AWAITING"
+	WebhookStatusUnpacked          = "UNPACKED"
+	WebhookStatusAtCollectionPoint = "AT_COLLECTION_POINT"
+	WebhookStatusVerified          = "VERIFIED"
+)
+
+// DefaultWebhookAPIKeyHeader is the header carrying the key of API_KEY subscriptions
+const DefaultWebhookAPIKeyHeader = "x-api-key"
+
+// maxWebhookBody limits the size of a pushed payload
+const maxWebhookBody = 10 << 20
+
+// webhookStatuses maps package statuses to their subscription form. Some
+// pushed statuses have no Status constant.
+var webhookStatuses = map[PackageStatus]string{
+	StatusAwaiting:      WebhookStatusAwaiting,
+	StatusCreated:       WebhookStatusCreated,
+	StatusPicking:       WebhookStatusPicking,
+	StatusInvoiced:      WebhookStatusInvoiced,
+	StatusShipped:       WebhookStatusShipped,
+	StatusDelivered:     WebhookStatusDelivered,
+	StatusCancelled:     WebhookStatusCancelled,
+	StatusUnpacked:      WebhookStatusUnpacked,
+	StatusReturned:      WebhookStatusReturned,
+	StatusUnSupplied:    WebhookStatusUnsupplied,
+	"UnDelivered":       WebhookStatusUndelivered,
+	"AtCollectionPoint": WebhookStatusAtCollectionPoint,
+	"Verified":          WebhookStatusVerified,
+}
+
+// WebhookStatus converts a package status such as "AtCollectionPoint" to its
+// subscription form "AT_COLLECTION_POINT". Unknown statuses are upper-cased.
+func WebhookStatus(packageStatus PackageStatus) string {
+	if s, ok := webhookStatuses[packageStatus]; ok {
+		return s
+	}
+	return strings.ToUpper(string(packageStatus))
+}
+
+// webhookOrderStatus returns the subscription status of a pushed package,
+// falling back to shipmentPackageStatus when status is empty
+func webhookOrderStatus(o Order) string {
+	if o.Status != "" {
+		return WebhookStatus(o.Status)
+	}
+	return WebhookStatus(o.ShipmentPackageStatus)
+}
+
+// WebhookFunc handles a pushed shipment package
+type WebhookFunc func(ctx context.Context, order Order) error
+
+// Deduplicator remembers processed deliveries
+type Deduplicator interface {
+	// Seen reports whether key was recorded before and records it otherwise
+	Seen(key string) bool
+	// Forget removes key so that a redelivery is processed again
+	Forget(key string)
+}
+
+// memoryDeduplicator keeps keys in memory for a fixed period
+type memoryDeduplicator struct {
+	ttl  time.Duration
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewMemoryDeduplicator returns an in-memory Deduplicator that forgets keys
+// after ttl. Trendyol retries failed deliveries every 5 minutes, so ttl should
+// cover at least a few retry rounds.
+func NewMemoryDeduplicator(ttl time.Duration) Deduplicator {
+	return &memoryDeduplicator{ttl: ttl, seen: map[string]time.Time{}}
+}
+
+func (d *memoryDeduplicator) Seen(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	for k, t := range d.seen {
+		if now.Sub(t) > d.ttl {
+			delete(d.seen, k)
+		}
+	}
+	if _, ok := d.seen[key]; ok {
+		return true
+	}
+	d.seen[key] = now
+	return false
+}
+
+func (d *memoryDeduplicator) Forget(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.seen, key)
+}
+
+// WebhookHandlerOption configures a WebhookHandler
+type WebhookHandlerOption func(*WebhookHandler)
+
+// WithDeduplicator replaces the default in-memory deduplicator
+func WithDeduplicator(d Deduplicator) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.dedup = d
+	}
+}
+
+// WithAPIKeyHeader sets the header checked for API_KEY subscriptions
+func WithAPIKeyHeader(name string) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.apiKeyHeader = name
+	}
+}
+
+// WithWebhookErrorHandler is called for every rejected or failed delivery
+func WithWebhookErrorHandler(fn func(r *http.Request, err error)) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.onError = fn
+	}
+}
+
+// WebhookHandler is an http.Handler receiving Trendyol order webhooks. It
+// verifies the credentials of the subscription, decodes the payload into
+// Order, drops repeated deliveries of the same package ID, status and
+// lastModifiedDate and dispatches to the callbacks registered for the status.
+//
+// A callback error answers 500 so that Trendyol redelivers the package later.
+type WebhookHandler struct {
+	sub          CreateWebhookRequest
+	apiKeyHeader string
+	dedup        Deduplicator
+	onError      func(r *http.Request, err error)
+
+	mu       sync.RWMutex
+	handlers map[string][]WebhookFunc
+	any      []WebhookFunc
+}
+
+// NewWebhookHandler creates a handler for the subscription registered with sub.
+// The same request used for Webhooks.Create provides the expected credentials.
+func NewWebhookHandler(sub CreateWebhookRequest, opts ...WebhookHandlerOption) *WebhookHandler {
+	h := &WebhookHandler{
+		sub:          sub,
+		apiKeyHeader: DefaultWebhookAPIKeyHeader,
+		dedup:        NewMemoryDeduplicator(time.Hour),
+		handlers:     map[string][]WebhookFunc{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// On registers fn for a subscription status such as WebhookStatusCreated
+func (h *WebhookHandler) On(status string, fn WebhookFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[status] = append(h.handlers[status], fn)
+}
+
+// OnAny registers fn for every status
+func (h *WebhookHandler) OnAny(fn WebhookFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.any = append(h.any, fn)
+}
+
+// ServeHTTP implements http.Handler
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.fail(w, r, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	if err := h.authenticate(r); err != nil {
+		h.fail(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, fmt.Errorf("failed to read webhook body: %w", err))
+		return
+	}
+	orders, err := decodeWebhookOrders(body)
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	for _, o := range orders {
+		status := webhookOrderStatus(o)
+		key := fmt.Sprintf("%d:%s:%d", o.ID, status, o.LastModifiedDate)
+		if h.dedup.Seen(key) {
+			continue
+		}
+		if err := h.dispatch(r.Context(), status, o); err != nil {
+			h.dedup.Forget(key)
+			h.fail(w, r, http.StatusInternalServerError, fmt.Errorf("package %d (%s): %w", o.ID, status, err))
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *WebhookHandler) authenticate(r *http.Request) error {
+	switch h.sub.AuthenticationType {
+	case WebhookAuthBasic:
+		if h.sub.Username == "" || h.sub.Password == "" {
+			return fmt.Errorf("webhook handler has no basic auth credentials configured")
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || !secureEqual(user, h.sub.Username) || !secureEqual(pass, h.sub.Password) {
+			return fmt.Errorf("invalid basic auth credentials")
+		}
+	case WebhookAuthAPIKey:
+		if h.sub.APIKey == "" {
+			return fmt.Errorf("webhook handler has no api key configured")
+		}
+		if !secureEqual(r.Header.Get(h.apiKeyHeader), h.sub.APIKey) {
+			return fmt.Errorf("invalid api key")
+		}
+	default:
+		return fmt.Errorf("unsupported authentication type %q", h.sub.AuthenticationType)
+	}
+	return nil
+}
+
+func (h *WebhookHandler) dispatch(ctx context.Context, status string, o Order) error {
+	h.mu.RLock()
+	fns := append(append([]WebhookFunc(nil), h.handlers[status]...), h.any...)
+	h.mu.RUnlock()
+
+	for _, fn := range fns {
+		if err := fn(ctx, o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *WebhookHandler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.onError != nil {
+		h.onError(r, err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// decodeWebhookOrders accepts a single package or an array of packages
+func decodeWebhookOrders(body []byte) ([]Order, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var orders []Order
+		if err := json.Unmarshal(body, &orders); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook payload: %w", err)
+		}
+		return orders, nil
+	}
+	var o Order
+	if err := json.Unmarshal(body, &o); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
+	return []Order{o}, nil
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,172 @@
+package trendyol_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+)
+
+func webhookRequest(t *testing.T, orders ...trendyol.Order) *http.Request {
+	t.Helper()
+	var body []byte
+	var err error
+	if len(orders) == 1 {
+		body, err = json.Marshal(orders[0])
+	} else {
+		body, err = json.Marshal(orders)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(string(body)))
+}
+
+// TestWebhookHandlerBasicAuth kimlik doğrulamayı, statüye göre yönlendirmeyi ve
+// tekrarlanan gönderimlerin elendiğini doğrular.
+func TestWebhookHandlerBasicAuth(t *testing.T) {
+	h := trendyol.NewWebhookHandler(trendyol.CreateWebhookRequest{
+		AuthenticationType: trendyol.WebhookAuthBasic,
+		Username:           "hook",
+		Password:           "s3cret",
+	})
+	var created, shipped, all int
+	h.On(trendyol.WebhookStatusCreated, func(ctx context.Context, o trendyol.Order) error {
+		created++
+		return nil
+	})
+	h.On(trendyol.WebhookStatusShipped, func(ctx context.Context, o trendyol.Order) error {
+		shipped++
+		return nil
+	})
+	h.OnAny(func(ctx context.Context, o trendyol.Order) error {
+		all++
+		return nil
+	})
+
+	order := trendyol.Order{ID: 1, OrderNumber: "ORD-1", Status: trendyol.StatusCreated, LastModifiedDate: 1000}
+
+	req := webhookRequest(t, order)
+	req.SetBasicAuth("hook", "wrong")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized || created != 0 {
+		t.Fatalf("yanlış parola reddedilmeliydi: %d", rec.Code)
+	}
+
+	for i := 0; i < 2; i++ {
+		req = webhookRequest(t, order)
+		req.SetBasicAuth("hook", "s3cret")
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("200 beklendi: %d", rec.Code)
+		}
+	}
+	if created != 1 || all != 1 {
+		t.Errorf("tekrarlanan gönderim elenmeliydi: created=%d all=%d", created, all)
+	}
+
+	order.Status = trendyol.StatusShipped
+	order.LastModifiedDate = 2000
+	req = webhookRequest(t, order)
+	req.SetBasicAuth("hook", "s3cret")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if shipped != 1 || all != 2 {
+		t.Errorf("SHIPPED geri çağrısı bekleniyordu: shipped=%d all=%d", shipped, all)
+	}
+}
+
+// TestWebhookHandlerAPIKeyRetry API anahtarı doğrulamasını ve geri çağrı hatasında
+// aynı paketin yeniden işlenebildiğini doğrular.
+func TestWebhookHandlerAPIKeyRetry(t *testing.T) {
+	h := trendyol.NewWebhookHandler(trendyol.CreateWebhookRequest{
+		AuthenticationType: trendyol.WebhookAuthAPIKey,
+		APIKey:             "key-1",
+	})
+	calls := 0
+	h.On(trendyol.WebhookStatusAtCollectionPoint, func(ctx context.Context, o trendyol.Order) error {
+		calls++
+		if calls == 1 {
+			return errors.New("db down")
+		}
+		return nil
+	})
+
+	order := trendyol.Order{ID: 7, Status: "AtCollectionPoint", LastModifiedDate: 5}
+	send := func() int {
+		req := webhookRequest(t, order)
+		req.Header.Set("x-api-key", "key-1")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if code := send(); code != http.StatusInternalServerError {
+		t.Fatalf("geri çağrı hatasında 500 beklendi: %d", code)
+	}
+	if code := send(); code != http.StatusOK || calls != 2 {
+		t.Fatalf("yeniden gönderim işlenmeliydi: %d calls=%d", code, calls)
+	}
+
+	req := webhookRequest(t, order)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("anahtarsız istek reddedilmeliydi: %d", rec.Code)
+	}
+}
+
+// TestWebhookStatus her paket statüsünün abonelik biçimine çevrildiğini doğrular.
+func TestWebhookStatus(t *testing.T) {
+	cases := map[trendyol.PackageStatus]string{
+		trendyol.StatusAwaiting:   trendyol.WebhookStatusAwaiting,
+		trendyol.StatusCreated:    trendyol.WebhookStatusCreated,
+		trendyol.StatusPicking:    trendyol.WebhookStatusPicking,
+		trendyol.StatusInvoiced:   trendyol.WebhookStatusInvoiced,
+		trendyol.StatusShipped:    trendyol.WebhookStatusShipped,
+		trendyol.StatusDelivered:  trendyol.WebhookStatusDelivered,
+		trendyol.StatusCancelled:  trendyol.WebhookStatusCancelled,
+		trendyol.StatusUnpacked:   trendyol.WebhookStatusUnpacked,
+		trendyol.StatusReturned:   trendyol.WebhookStatusReturned,
+		trendyol.StatusUnSupplied: trendyol.WebhookStatusUnsupplied,
+		"UnDelivered":             trendyol.WebhookStatusUndelivered,
+		"AtCollectionPoint":       trendyol.WebhookStatusAtCollectionPoint,
+		"Verified":                trendyol.WebhookStatusVerified,
+	}
+	if len(cases) != len(trendyol.AllWebhookStatuses) {
+		t.Fatalf("tablo tüm abonelik statülerini kapsamalı: %d/%d", len(cases), len(trendyol.AllWebhookStatuses))
+	}
+	for in, want := range cases {
+		if got := trendyol.WebhookStatus(in); got != want {
+			t.Errorf("WebhookStatus(%q) = %q, beklenen %q", in, got, want)
+		}
+	}
+}
+
+// TestWebhookHandlerPackageStatusDedup yalnızca shipmentPackageStatus taşıyan
+// gönderimlerde farklı statülerin tekrar sayılmadığını doğrular.
+func TestWebhookHandlerPackageStatusDedup(t *testing.T) {
+	h := trendyol.NewWebhookHandler(trendyol.CreateWebhookRequest{
+		AuthenticationType: trendyol.WebhookAuthAPIKey,
+		APIKey:             "key-1",
+	})
+	var got []string
+	h.OnAny(func(ctx context.Context, o trendyol.Order) error {
+		got = append(got, string(o.ShipmentPackageStatus))
+		return nil
+	})
+
+	for _, status := range []trendyol.PackageStatus{trendyol.StatusPicking, trendyol.StatusInvoiced, trendyol.StatusInvoiced} {
+		req := webhookRequest(t, trendyol.Order{ID: 9, ShipmentPackageStatus: status, LastModifiedDate: 10})
+		req.Header.Set("x-api-key", "key-1")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if len(got) != 2 || got[0] != "Picking" || got[1] != "Invoiced" {
+		t.Errorf("iki farklı statü bir kez işlenmeliydi: %v", got)
+	}
+}
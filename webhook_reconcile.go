@@ -0,0 +1,224 @@
+package trendyol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Webhook reconcile actions
+const (
+	WebhookActionCreate     = "CREATE"
+	WebhookActionUpdate     = "UPDATE"
+	WebhookActionDelete     = "DELETE"
+	WebhookActionActivate   = "ACTIVATE"
+	WebhookActionDeactivate = "DEACTIVATE"
+)
+
+// AllWebhookStatuses is what an empty SubscribedStatuses list subscribes to
+var AllWebhookStatuses = []string{
+	WebhookStatusCreated, WebhookStatusPicking, WebhookStatusInvoiced, WebhookStatusShipped,
+	WebhookStatusCancelled, WebhookStatusDelivered, WebhookStatusUndelivered, WebhookStatusReturned,
+	WebhookStatusUnsupplied, WebhookStatusAwaiting, WebhookStatusUnpacked,
+	WebhookStatusAtCollectionPoint, WebhookStatusVerified,
+}
+
+// WebhookChange is a single step of a reconcile plan
+type WebhookChange struct {
+	Action string
+	ID     string // empty for creations until applied
+	URL    string
+	Fields []string // changed fields of an update
+	Err    error    // set when applying the change failed
+}
+
+func (c WebhookChange) String() string {
+	if len(c.Fields) > 0 {
+		return fmt.Sprintf("%s %s (%v)", c.Action, c.URL, c.Fields)
+	}
+	return fmt.Sprintf("%s %s", c.Action, c.URL)
+}
+
+// ReconcileOption configures Webhooks.Reconcile
+type ReconcileOption func(*reconcileConfig)
+
+type reconcileConfig struct {
+	dryRun    bool
+	unmanaged string // action for subscriptions not in desired, empty to keep them
+}
+
+// WithDryRun only returns the plan without calling the API
+func WithDryRun() ReconcileOption {
+	return func(c *reconcileConfig) {
+		c.dryRun = true
+	}
+}
+
+// WithKeepUnmanaged leaves subscriptions whose URL is not desired untouched.
+// This is the default; the option undoes an earlier WithPruneUnmanaged.
+func WithKeepUnmanaged() ReconcileOption {
+	return func(c *reconcileConfig) {
+		c.unmanaged = ""
+	}
+}
+
+// WithDeactivateUnmanaged deactivates subscriptions whose URL is not desired
+func WithDeactivateUnmanaged() ReconcileOption {
+	return func(c *reconcileConfig) {
+		c.unmanaged = WebhookActionDeactivate
+	}
+}
+
+// WithPruneUnmanaged deletes subscriptions whose URL is not desired. Only use
+// it when desired lists every subscription of the seller, including those of
+// other environments sharing the account.
+func WithPruneUnmanaged() ReconcileOption {
+	return func(c *reconcileConfig) {
+		c.unmanaged = WebhookActionDelete
+	}
+}
+
+// Reconcile converges the seller's subscriptions to desired, matching them by
+// URL. Missing subscriptions are created, differing ones updated and inactive
+// ones activated. Subscriptions with other URLs are left alone unless
+// WithPruneUnmanaged or WithDeactivateUnmanaged is given. Deletions run first
+// so that creations do not hit the subscription limit.
+//
+// Passwords and API keys are only compared when List returns them. The
+// returned plan lists every change; with WithDryRun nothing is applied.
+func (s *webhookService) Reconcile(ctx context.Context, desired []CreateWebhookRequest, opts ...ReconcileOption) ([]WebhookChange, error) {
+	var cfg reconcileConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	wanted := make(map[string]CreateWebhookRequest, len(desired))
+	for _, d := range desired {
+		if d.URL == "" {
+			return nil, errors.New("desired webhook without url")
+		}
+		if _, dup := wanted[d.URL]; dup {
+			return nil, fmt.Errorf("duplicate desired webhook url: %s", d.URL)
+		}
+		wanted[d.URL] = d
+	}
+
+	existing, err := s.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	var removals, updates, creations []WebhookChange
+	matched := map[string]Webhook{}
+	for _, wh := range existing {
+		if _, ok := wanted[wh.URL]; !ok {
+			if cfg.unmanaged == WebhookActionDelete || (cfg.unmanaged == WebhookActionDeactivate && wh.Active) {
+				removals = append(removals, WebhookChange{Action: cfg.unmanaged, ID: wh.ID, URL: wh.URL})
+			}
+			continue
+		}
+		if _, dup := matched[wh.URL]; dup {
+			// Only one subscription per URL is kept
+			removals = append(removals, WebhookChange{Action: WebhookActionDelete, ID: wh.ID, URL: wh.URL})
+			continue
+		}
+		matched[wh.URL] = wh
+	}
+
+	for _, d := range desired {
+		wh, ok := matched[d.URL]
+		if !ok {
+			creations = append(creations, WebhookChange{Action: WebhookActionCreate, URL: d.URL})
+			continue
+		}
+		if fields := webhookDiff(wh, d); len(fields) > 0 {
+			updates = append(updates, WebhookChange{Action: WebhookActionUpdate, ID: wh.ID, URL: d.URL, Fields: fields})
+		}
+		if !wh.Active {
+			updates = append(updates, WebhookChange{Action: WebhookActionActivate, ID: wh.ID, URL: d.URL})
+		}
+	}
+
+	plan := append(append(removals, updates...), creations...)
+	if cfg.dryRun {
+		return plan, nil
+	}
+
+	var errs []error
+	for i := range plan {
+		c := &plan[i]
+		switch c.Action {
+		case WebhookActionDelete:
+			c.Err = s.Delete(ctx, c.ID)
+		case WebhookActionDeactivate:
+			c.Err = s.Deactivate(ctx, c.ID)
+		case WebhookActionActivate:
+			c.Err = s.Activate(ctx, c.ID)
+		case WebhookActionUpdate:
+			d := wanted[c.URL]
+			c.Err = s.Update(ctx, c.ID, UpdateWebhookRequest{
+				URL:                d.URL,
+				Username:           d.Username,
+				Password:           d.Password,
+				AuthenticationType: d.AuthenticationType,
+				APIKey:             d.APIKey,
+				SubscribedStatuses: subscribedStatuses(d.SubscribedStatuses),
+			})
+		case WebhookActionCreate:
+			c.ID, c.Err = s.Create(ctx, wanted[c.URL])
+		}
+		if c.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c, c.Err))
+		}
+	}
+	return plan, errors.Join(errs...)
+}
+
+// webhookDiff returns the names of the fields that differ from desired
+func webhookDiff(wh Webhook, d CreateWebhookRequest) []string {
+	var fields []string
+	if wh.AuthenticationType != d.AuthenticationType {
+		fields = append(fields, "authenticationType")
+	}
+	if wh.Username != d.Username && (wh.Username != "" || d.Username != "") {
+		fields = append(fields, "username")
+	}
+	if wh.Password != "" && wh.Password != d.Password {
+		fields = append(fields, "password")
+	}
+	if wh.APIKey != "" && wh.APIKey != d.APIKey {
+		fields = append(fields, "apiKey")
+	}
+	if !sameStatuses(wh.SubscribedStatuses, d.SubscribedStatuses) {
+		fields = append(fields, "subscribedStatuses")
+	}
+	return fields
+}
+
+// subscribedStatuses expands an empty list, which the update payload would omit
+func subscribedStatuses(statuses []string) []string {
+	if len(statuses) == 0 {
+		return AllWebhookStatuses
+	}
+	return statuses
+}
+
+func sameStatuses(a, b []string) bool {
+	a, b = sortedCopy(subscribedStatuses(a)), sortedCopy(subscribedStatuses(b))
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedCopy(s []string) []string {
+	out := append([]string(nil), s...)
+	sort.Strings(out)
+	return out
+}
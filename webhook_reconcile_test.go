@@ -0,0 +1,122 @@
+package trendyol_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vahaponur/trendyol-go"
+	"github.com/vahaponur/trendyol-go/trendyoltest"
+)
+
+func basicHook(url string, statuses ...string) trendyol.CreateWebhookRequest {
+	return trendyol.CreateWebhookRequest{
+		URL:                url,
+		AuthenticationType: trendyol.WebhookAuthBasic,
+		Username:           "u",
+		Password:           "p",
+		SubscribedStatuses: statuses,
+	}
+}
+
+// TestWebhookReconcile kuru çalıştırma planını, planın uygulanmasını ve
+// ikinci çalıştırmada değişiklik kalmadığını doğrular.
+func TestWebhookReconcile(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	keepID, err := client.Webhooks.Create(ctx, basicHook("https://a.example.com", trendyol.WebhookStatusCreated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Webhooks.Deactivate(ctx, keepID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Webhooks.Create(ctx, basicHook("https://old.example.com")); err != nil {
+		t.Fatal(err)
+	}
+
+	desired := []trendyol.CreateWebhookRequest{
+		basicHook("https://a.example.com", trendyol.WebhookStatusCreated, trendyol.WebhookStatusShipped),
+		basicHook("https://new.example.com"),
+	}
+
+	plan, err := client.Webhooks.Reconcile(ctx, desired, trendyol.WithDryRun(), trendyol.WithPruneUnmanaged())
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	want := []string{trendyol.WebhookActionDelete, trendyol.WebhookActionUpdate, trendyol.WebhookActionActivate, trendyol.WebhookActionCreate}
+	if len(plan) != len(want) {
+		t.Fatalf("beklenmeyen plan: %v", plan)
+	}
+	for i, c := range plan {
+		if c.Action != want[i] {
+			t.Errorf("adım %d: %s beklendi, gelen %s", i, want[i], c)
+		}
+	}
+	if n := len(srv.Calls(trendyol.EndpointDeleteWebhookKey, trendyol.EndpointCreateWebhookKey)); n != 2 {
+		t.Errorf("kuru çalıştırmada API çağrılmamalı (yalnızca kurulum istekleri), gelen %d", n)
+	}
+
+	if _, err := client.Webhooks.Reconcile(ctx, desired, trendyol.WithPruneUnmanaged()); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	hooks := srv.Webhooks()
+	if len(hooks) != 2 {
+		t.Fatalf("iki abonelik beklendi: %+v", hooks)
+	}
+	for _, h := range hooks {
+		if !h.Active {
+			t.Errorf("%s aktif olmalı", h.URL)
+		}
+		if h.URL == "https://a.example.com" && (h.ID != keepID || len(h.SubscribedStatuses) != 2) {
+			t.Errorf("mevcut abonelik güncellenmeliydi: %+v", h)
+		}
+	}
+
+	plan, err = client.Webhooks.Reconcile(ctx, desired)
+	if err != nil || len(plan) != 0 {
+		t.Errorf("ikinci çalıştırmada plan boş olmalı: %v %v", plan, err)
+	}
+}
+
+// TestWebhookReconcileUnmanaged istenmeyen aboneliklerin varsayılan olarak
+// korunduğunu ve pasife alınabildiğini doğrular.
+func TestWebhookReconcileUnmanaged(t *testing.T) {
+	srv := trendyoltest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	if _, err := client.Webhooks.Create(ctx, basicHook("https://other.example.com")); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := client.Webhooks.Reconcile(ctx, []trendyol.CreateWebhookRequest{basicHook("https://mine.example.com")})
+	if err != nil || len(plan) != 1 || plan[0].Action != trendyol.WebhookActionCreate {
+		t.Fatalf("yalnızca oluşturma planı beklendi: %v %v", plan, err)
+	}
+	if hooks := srv.Webhooks(); len(hooks) != 2 || hooks[0].URL != "https://other.example.com" || !hooks[0].Active {
+		t.Fatalf("diğer ortamın aboneliği korunmalıydı: %+v", hooks)
+	}
+
+	plan, err = client.Webhooks.Reconcile(ctx, nil, trendyol.WithPruneUnmanaged(), trendyol.WithKeepUnmanaged())
+	if err != nil || len(plan) != 0 {
+		t.Fatalf("diğer abonelikler korunmalıydı: %v %v", plan, err)
+	}
+
+	plan, err = client.Webhooks.Reconcile(ctx, nil, trendyol.WithDeactivateUnmanaged())
+	if err != nil || len(plan) != 2 || plan[0].Action != trendyol.WebhookActionDeactivate {
+		t.Fatalf("pasife alma planı beklendi: %v %v", plan, err)
+	}
+	for _, h := range srv.Webhooks() {
+		if h.Active {
+			t.Errorf("abonelik pasif olmalı: %+v", h)
+		}
+	}
+
+	if _, err := client.Webhooks.Reconcile(ctx, []trendyol.CreateWebhookRequest{basicHook("x"), basicHook("x")}); err == nil {
+		t.Error("tekrarlanan URL hata vermeli")
+	}
+}